@@ -1,26 +1,37 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/jbdoumenjou/baeker/cmd"
+	"github.com/jbdoumenjou/baeker/pkg/export"
+	"github.com/jbdoumenjou/baeker/pkg/importer"
 	"github.com/spf13/cobra"
 )
 
-const (
-	inDockerCompose          = "In a Docker Compose File"
-	asKubernetesLoadBalancer = "As a Kubernetes Load Balancer"
-	asTOMLFile               = "As a Toml File"
-	asYAMLFile               = "As a Yaml File"
-	asCLI                    = "As CLI"
-)
-
 func main() {
+	// The user templates are available as output formats.
+	templates, err := export.NewTemplateRegistry(cmd.DefaultTemplateDirs()...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot load the user templates: %v\n", err)
+	} else if err := export.Formats.RegisterTemplates(templates); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot register the user templates: %v\n", err)
+	}
+
 	rootCmd := createRootCmd()
 	rootCmd.AddCommand(createExportCmd())
+	rootCmd.AddCommand(createSchemaCmd())
+	rootCmd.AddCommand(createExplainCmd())
+	rootCmd.AddCommand(createValidateCmd())
+	rootCmd.AddCommand(createTemplatesCmd())
+	rootCmd.AddCommand(createCompletionCmd(rootCmd))
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -40,198 +51,287 @@ func createRootCmd() *cobra.Command {
 }
 
 func rootRun() error {
-	answers := struct {
-		Provider string `survey:"provider"`
-	}{}
-
-	// perform the questions
-	err := survey.Ask([]*survey.Question{
-		{
-			Name: "Provider",
-			Prompt: &survey.Select{
-				Message: "Where do you want to define Traefik?",
-				Options: []string{inDockerCompose, asKubernetesLoadBalancer, asTOMLFile, asYAMLFile, asCLI},
-				Default: inDockerCompose,
-				Help:    "https://doc.traefik.io/traefik/v2.4/providers/overview/#supported-providers",
-			},
-		},
-	}, &answers)
+	formats := export.Formats.List()
+
+	answers, err := askWizard(formats)
 	if err != nil {
 		return fmt.Errorf("cannot create survey:%w", err)
 	}
 
-	switch answers.Provider {
-	case inDockerCompose:
-		exportToDockerCompose()
-	case asKubernetesLoadBalancer:
-		exportToKubernetesCRD()
-	case asTOMLFile:
-		exportToTomlFile()
-	case asYAMLFile:
-		exportToYamlFile()
-	case asCLI:
-		exportToCLI()
-	default:
-		fmt.Printf("%s not supported", answers.Provider)
+	for _, format := range formats {
+		if format.Description == answers.Format {
+			exportToFile(format, answers)
+			return nil
+		}
 	}
 
+	fmt.Printf("%s not supported", answers.Format)
+
 	return nil
 }
 
 func createExportCmd() *cobra.Command {
-	var to string
+	var (
+		opts      cmd.ExportOptions
+		style     string
+		dashboard export.Dashboard
+	)
 	cmd := &cobra.Command{
-		Use:     "export [file path]",
+		Use:     "export [file path|-]",
 		Aliases: []string{"e"},
-		Short:   "Exports a yml static configuration file to standard output with a specified format.",
-		Long:    "Exports a yml static configuration file to standard output with a specified format.",
-		RunE: func(_ *cobra.Command, args []string) error {
-			confReader, err := os.Open(filepath.FromSlash(args[0]))
+		Short:   "Exports a static configuration file to standard output with a specified format.",
+		Long: `Exports a static configuration file to standard output with a specified format.
+The source format is detected from the file extension and, failing that, from the content.
+Use - as file path to read the configuration from the standard input.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(command *cobra.Command, args []string) error {
+			if command.Flags().Changed("style") {
+				opts.Style = export.CLIStyle(style)
+			}
+			if dashboard.Host != "" {
+				opts.Dashboard = &dashboard
+			}
+
+			// Without file, the environment variables come from the current process.
+			if opts.From == importer.FormatEnv && len(args) == 0 {
+				conf, err := importer.Env(os.Environ())
+				if err != nil {
+					return fmt.Errorf("cannot import environment variables: %w", err)
+				}
+
+				return cmd.ExportConfCmd(conf, opts)
+			}
+
+			if len(args) == 0 {
+				return errors.New("missing source file path")
+			}
+
+			content, err := readSource(args[0])
 			if err != nil {
-				return fmt.Errorf("cannot open source file:%w", err)
+				return fmt.Errorf("cannot read source file:%w", err)
 			}
-			err = cmd.ExportCmd(confReader, to)
+
+			if opts.From == "" {
+				opts.From = importer.DetectFormat(args[0], content)
+			}
+
+			err = cmd.ExportCmd(bytes.NewReader(content), opts)
 			if err != nil {
-				return fmt.Errorf("cannot export %s to %s: %w", args[0], to, err)
+				return fmt.Errorf("cannot export %s to %s: %w", args[0], opts.To, err)
 			}
 
 			return nil
 		},
 		Example: `  $ baeker export traefik.yml
-  $ baeker e traefik.yml`,
-	}
-	cmd.Flags().StringVarP(&to, "to", "t", "cli", "to output format")
+  $ baeker e traefik.yml
+  $ baeker export --from cli --to yaml command.txt
+  $ baeker export --from env --to yaml traefik.env
+  $ cat traefik.toml | baeker export --to yaml -
+  $ TRAEFIK_PROVIDERS_DOCKER=true baeker export --from env --to yaml
+  $ baeker export --to cli --style json traefik.yml
+  $ baeker export --to yaml --include-defaults traefik.yml
+  $ baeker export --to docker --template my-compose-tpl.yml traefik.yml
+  $ baeker export --to acme-k8s traefik.yml
+  $ baeker export --to docker --dashboard-host traefik.example.com --dashboard-user "$(htpasswd -nbB admin secret)" traefik.yml`,
+	}
+	cmd.Flags().StringVarP(&opts.From, "from", "f", "", fmt.Sprintf("from input format (%s), detected when omitted", strings.Join(importer.Formats.Values(), ", ")))
+	cmd.Flags().BoolVar(&opts.Strict, "strict", true, "reject the unknown keys of yaml and toml files")
+	cmd.Flags().StringVarP(&style, "style", "s", "inline", "style of the cli output, cli only (inline, multiline, json)")
+	cmd.Flags().StringVar(&dashboard.Host, "dashboard-host", "", "host of the router exposing the secure dashboard in the docker and kubernetes outputs")
+	cmd.Flags().StringSliceVar(&dashboard.Users, "dashboard-user", nil, "basic auth user of the dashboard router, in the htpasswd format (i.e. admin:$2y$05$...)")
+	cmd.Flags().StringVar(&dashboard.EntryPoint, "dashboard-entrypoint", "", "entry point of the dashboard router, websecure or web when omitted")
+	cmd.Flags().BoolVar(&opts.ServiceMonitor, "service-monitor", false, "add a Prometheus Operator ServiceMonitor to the kubernetes output")
+	cmd.Flags().BoolVar(&opts.TracingCompanion, "tracing-companion", false, "run a local Jaeger or Zipkin next to Traefik in the docker and kubernetes outputs")
+	cmd.Flags().BoolVar(&opts.PublishInternal, "publish-internal", false, "publish the traefik entry point (insecure API, metrics) in the docker and kubernetes outputs")
+	cmd.Flags().BoolVar(&opts.IncludeDefaults, "include-defaults", false, "also export the options set to their default value, to print the effective configuration")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "template file overriding the one of a template output (i.e. docker, kubernetes)")
+	cmd.Flags().StringVarP(&opts.To, "to", "t", "cli", fmt.Sprintf("to output format (%s)", strings.Join(export.Formats.Values(), ", ")))
+	markFlagValues(cmd, "from", importer.Formats.Values())
+	markFlagValues(cmd, "to", export.Formats.Values())
 
 	return cmd
 }
 
-func exportToDockerCompose() {
-	if _, err := os.Stat("output"); os.IsNotExist(err) {
-		err := os.Mkdir("out", 0o750)
-		if err != nil && !os.IsExist(err) {
-			fmt.Printf("Cannot create directory to export conf: %v", err)
-			return
-		}
-	}
+func createValidateCmd() *cobra.Command {
+	var (
+		from   string
+		strict bool
+	)
+	cmd := &cobra.Command{
+		Use:     "validate [file path|-]",
+		Aliases: []string{"v"},
+		Short:   "Validates a static configuration file.",
+		Long: `Validates a static configuration file and prints the problems found.
+The command exits with a non-zero status when the configuration has errors.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			content, err := readSource(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot read source file:%w", err)
+			}
 
-	f, err := os.Create("./out/docker-compose.yml")
-	if err != nil {
-		fmt.Println("cannot create file: ", err)
-		return
-	}
+			if from == "" {
+				from = importer.DetectFormat(args[0], content)
+			}
 
-	builder, err := cmd.NewStaticConfBuilder().AddDockerProvider()
-	if err != nil {
-		fmt.Printf("cannot add Docker provider to the static configuration: %s", err)
-		return
+			return cmd.ValidateCmd(bytes.NewReader(content), from, strict)
+		},
+		Example: `  $ baeker validate traefik.yml
+  $ baeker v --from cli command.txt
+  $ baeker validate --strict=false traefik.toml`,
 	}
+	cmd.Flags().StringVarP(&from, "from", "f", "", fmt.Sprintf("from input format (%s), detected when omitted", strings.Join(importer.Formats.Values(), ", ")))
+	cmd.Flags().BoolVar(&strict, "strict", true, "reject the unknown keys of yaml and toml files")
+	markFlagValues(cmd, "from", importer.Formats.Values())
 
-	_, err = builder.AddEntryPoint("web", ":8000")
-	if err != nil {
-		fmt.Printf("cannot AddEntryPoint to the static configuration: %s", err)
-		return
-	}
+	return cmd
+}
 
-	_, err = builder.AddEntryPoint("websecure", ":8443")
-	if err != nil {
-		fmt.Printf("cannot AddEntryPoint to the static configuration: %s", err)
-		return
+func createSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Prints the JSON Schema of the Traefik static configuration.",
+		Long: `Prints the JSON Schema of the Traefik static configuration.
+The schema can be used by editors to validate and autocomplete the Traefik configuration files.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return cmd.ExportSchema(os.Stdout)
+		},
+		Example: `  $ baeker schema > traefik-schema.json`,
 	}
+}
 
-	err = cmd.ExportDocker(builder.GetConfiguration(), "./cmd/docker-compose-tpl.yml", f)
-	if err != nil {
-		fmt.Printf("Failed to export Traefik configuration in %s: %q\n", f.Name(), err.Error())
-		return
-	}
+func createExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain [key]",
+		Short: "Describes an option of the Traefik static configuration.",
+		Long: `Describes an option of the Traefik static configuration: its type, default value and description,
+and how to set it in the yaml, toml, CLI and environment variable formats.
+The options of a section are listed, and all the options when no key is given.
+The key can be written in any of these formats, and the map keys are free (i.e. entryPoints.web.address).`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			var key string
+			if len(args) > 0 {
+				key = args[0]
+			}
 
-	fmt.Printf("Successfully exported Traefik configuration in %s\n", f.Name())
+			return cmd.ExplainCmd(key, os.Stdout)
+		},
+		Example: `  $ baeker explain providers.docker.exposedByDefault
+  $ baeker explain providers.docker
+  $ baeker explain TRAEFIK_ENTRYPOINTS_WEB_ADDRESS
+  $ baeker explain | less`,
+	}
 }
 
-func exportToKubernetesCRD() {
-	if _, err := os.Stat("output"); os.IsNotExist(err) {
-		err := os.Mkdir("out", 0o750)
-		if err != nil && !os.IsExist(err) {
-			fmt.Printf("Cannot create directory to export conf: %v", err)
-			return
-		}
+func createTemplatesCmd() *cobra.Command {
+	templatesCmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Manages the export templates.",
+		Long: `Manages the export templates.
+The user templates are discovered in ~/.config/baeker/templates and .baeker/templates,
+and can be used as output format of the export command.`,
 	}
 
-	f, err := os.Create("./out/traefik-lb-svc.yml")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
+	templatesCmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Lists the available export templates.",
+		Args:    cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			registry, err := export.NewTemplateRegistry(cmd.DefaultTemplateDirs()...)
+			if err != nil {
+				return fmt.Errorf("cannot load the templates: %w", err)
+			}
 
-	builder, err := cmd.NewStaticConfBuilder().AddKubernetesProvider()
-	if err != nil {
-		fmt.Printf("cannot create static configuration: %s", err)
-		return
-	}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tOUTPUT\tSOURCE\tDESCRIPTION")
+			for _, info := range registry.List() {
+				source := info.Path
+				if info.Bundled {
+					source = "bundled"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", info.Name, info.Output, source, info.Description)
+			}
 
-	_, err = builder.AddEntryPoint("web", ":8000")
-	if err != nil {
-		fmt.Printf("cannot AddEntryPoint to the static configuration: %s", err)
-		return
-	}
+			return w.Flush()
+		},
+		Example: `  $ baeker templates list`,
+	})
 
-	_, err = builder.AddEntryPoint("websecure", ":8443")
-	if err != nil {
-		fmt.Printf("cannot AddEntryPoint to the static configuration: %s", err)
-		return
-	}
+	return templatesCmd
+}
 
-	err = cmd.ExportDocker(builder.GetConfiguration(), "./cmd/traefik-lb-svc-tpl.yml", f)
-	if err != nil {
-		fmt.Printf("Failed to export Traefik configuration in %s: %q\n", f.Name(), err.Error())
-		return
+// completeWordsFunc is the bash function used to complete the flags with a list of values.
+const completeWordsFunc = `__baeker_complete_words()
+{
+    COMPREPLY=( $(compgen -W "$*" -- "$cur") )
+}`
+
+func createCompletionCmd(rootCmd *cobra.Command) *cobra.Command {
+	rootCmd.BashCompletionFunction = completeWordsFunc
+
+	return &cobra.Command{
+		Use:   "completion",
+		Short: "Prints the bash completion script.",
+		Long: `Prints the bash completion script.
+The values of the --from and --to flags are completed with the registered formats.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return rootCmd.GenBashCompletion(os.Stdout)
+		},
+		Example: `  $ source <(baeker completion)`,
 	}
+}
 
-	fmt.Printf("Successfully exported Traefik configuration in %s\n", f.Name())
+// markFlagValues completes the flag with the given values in the bash completion script.
+func markFlagValues(command *cobra.Command, name string, values []string) {
+	_ = cobra.MarkFlagCustom(command.Flags(), name, "__baeker_complete_words "+strings.Join(values, " "))
 }
 
-func exportToTomlFile() {
-	if _, err := os.Stat("output"); os.IsNotExist(err) {
-		err := os.Mkdir("out", 0o750)
-		if err != nil && !os.IsExist(err) {
-			fmt.Printf("Cannot create directory to export conf: %v", err)
-			return
-		}
+// readSource reads the content of the given file, or of the standard input if the path is "-".
+func readSource(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
 	}
 
-	f, err := os.Create("./out/traefik.toml")
-	if err != nil {
-		fmt.Println("create file: ", err)
-		return
-	}
+	return ioutil.ReadFile(filepath.FromSlash(path))
+}
 
-	builder, err := cmd.NewStaticConfBuilder().AddFileProvider("conf")
+// exportToFile exports a configuration built for the format to its file in the out directory,
+// or to the standard output when the format has no file name.
+func exportToFile(format export.Format, answers wizardAnswers) {
+	conf, err := newWizardBuilder(format.Name, answers).Build()
 	if err != nil {
-		fmt.Printf("cannot AddFileProvider to the static configuration: %s", err)
+		fmt.Printf("cannot create static configuration: %s", err)
 		return
 	}
 
-	_, err = builder.AddEntryPoint("web", ":8000")
-	if err != nil {
-		fmt.Printf("cannot AddEntryPoint to the static configuration: %s", err)
-		return
+	opts := export.Options{
+		Style:     export.CLIStyleInline,
+		Dashboard: answers.DashboardRouter,
 	}
-
-	_, err = builder.AddEntryPoint("websecure", ":8443")
-	if err != nil {
-		fmt.Printf("cannot AddEntryPoint to the static configuration: %s", err)
-		return
+	if answers.Metrics != nil {
+		opts.ServiceMonitor = answers.Metrics.ServiceMonitor
+	}
+	if answers.Tracing != nil {
+		opts.TracingCompanion = answers.Tracing.Companion
 	}
 
-	err = cmd.ExportToml(builder.GetConfiguration(), f)
-	if err != nil {
-		fmt.Printf("Failed to export Traefik configuration in %s: %q\n", f.Name(), err.Error())
+	if format.FileName == "" {
+		err = format.Exporter.Export(conf, opts, os.Stdout)
+		if err != nil {
+			fmt.Printf("Failed to export Traefik configuration: %q\n", err.Error())
+			return
+		}
+
+		fmt.Println("Successfully exported Traefik configuration")
 		return
 	}
 
-	fmt.Printf("Successfully exported Traefik configuration in %s\n", f.Name())
-}
-
-func exportToYamlFile() {
 	if _, err := os.Stat("output"); os.IsNotExist(err) {
 		err := os.Mkdir("out", 0o750)
 		if err != nil && !os.IsExist(err) {
@@ -240,28 +340,14 @@ func exportToYamlFile() {
 		}
 	}
 
-	f, err := os.Create("./out/traefik.yaml")
+	f, err := os.Create(filepath.Join("out", format.FileName))
 	if err != nil {
-		fmt.Println("create file: ", err)
+		fmt.Println("cannot create file: ", err)
 		return
 	}
+	defer func() { _ = f.Close() }()
 
-	builder, err := cmd.NewStaticConfBuilder().AddFileProvider("conf")
-	if err != nil {
-		fmt.Printf("cannot create static configuration: %s", err)
-	}
-
-	_, err = builder.AddEntryPoint("web", ":8000")
-	if err != nil {
-		fmt.Printf("cannot create static configuration: %s", err)
-	}
-
-	_, err = builder.AddEntryPoint("websecure", ":8443")
-	if err != nil {
-		fmt.Printf("cannot create static configuration: %s", err)
-	}
-
-	err = cmd.ExportYaml(builder.GetConfiguration(), f)
+	err = format.Exporter.Export(conf, opts, f)
 	if err != nil {
 		fmt.Printf("Failed to export Traefik configuration in %s: %q\n", f.Name(), err.Error())
 		return
@@ -269,31 +355,3 @@ func exportToYamlFile() {
 
 	fmt.Printf("Successfully exported Traefik configuration in %s\n", f.Name())
 }
-
-func exportToCLI() {
-	builder, err := cmd.NewStaticConfBuilder().AddFileProvider("conf")
-	if err != nil {
-		fmt.Printf("cannot create static configuration: %s", err)
-		return
-	}
-
-	_, err = builder.AddEntryPoint("web", ":8000")
-	if err != nil {
-		fmt.Printf("Failed to add entrypoint: %q\n", err.Error())
-		return
-	}
-
-	_, err = builder.AddEntryPoint("websecure", ":8443")
-	if err != nil {
-		fmt.Printf("Failed to add entrypoint: %q\n", err.Error())
-		return
-	}
-
-	err = cmd.ExportCLI(builder.GetConfiguration(), os.Stdout)
-	if err != nil {
-		fmt.Printf("Failed to export Traefik configuration: %q\n", err.Error())
-		return
-	}
-
-	fmt.Println("Successfully exported Traefik configuration")
-}
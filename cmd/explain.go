@@ -0,0 +1,543 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"github.com/traefik/paerser/env"
+	"github.com/traefik/paerser/generator"
+	"github.com/traefik/paerser/parser"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"gopkg.in/yaml.v2"
+)
+
+// The types of the options holding other options.
+const (
+	sectionType = "section"
+	mapType     = "map"
+	listType    = "list"
+)
+
+// Option is an option of the Traefik static configuration, as described by the explain command.
+type Option struct {
+	// Key is the path of the option in the configuration files (i.e. providers.docker.exposedByDefault).
+	Key string
+	// Type is the type of the value (i.e. bool, duration, []string), or section, map and list for the options holding other ones.
+	Type string
+	// Default is the value used by Traefik when the option is not set, empty if there is none.
+	Default string
+	// Description is the description of the option in the Traefik static configuration.
+	Description string
+	// AllowEmpty reports whether the section is enabled by its presence alone (i.e. providers.docker).
+	AllowEmpty bool
+
+	path  []keySegment
+	value reflect.Value
+}
+
+type keySegment struct {
+	name string
+	// mapKey reports whether the segment is a map key, whose case is kept in the CLI flags.
+	mapKey bool
+	// list reports whether the segment is a list of sections, whose first entry holds the next segments.
+	list bool
+}
+
+// ExplainCmd prints the description of an option of the Traefik static configuration, and its spellings in each format.
+// The options of a section are listed, and all the options when the key is empty.
+func ExplainCmd(key string, output io.Writer) error {
+	option, err := Explain(key)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+
+	if option.Key != "" {
+		fmt.Fprintf(w, "Key:\t%s\n", option.Key)
+		fmt.Fprintf(w, "Type:\t%s\n", option.Type)
+		if option.isLeaf() {
+			fmt.Fprintf(w, "Default:\t%s\n", withDash(option.Default))
+		}
+		fmt.Fprintf(w, "Description:\t%s\n", withDash(option.Description))
+	}
+
+	if option.isLeaf() || option.AllowEmpty {
+		yamlSpelling, err := option.YAML()
+		if err != nil {
+			return err
+		}
+
+		tomlSpelling, err := option.TOML()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "\nYAML:\n%s", indent(yamlSpelling))
+		fmt.Fprintf(w, "\nTOML:\n%s", indent(tomlSpelling))
+		fmt.Fprintf(w, "\nCLI:\n%s", indent(option.CLI()))
+		fmt.Fprintf(w, "\nEnv:\n%s", indent(option.Env()))
+	}
+
+	if options := option.Options(); len(options) > 0 {
+		if option.Key != "" {
+			fmt.Fprintln(w, "\nOptions:")
+		}
+
+		fmt.Fprintln(w, "KEY\tTYPE\tDEFAULT\tDESCRIPTION")
+		for _, child := range options {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", child.Key, child.Type, withDash(child.Default), child.Description)
+		}
+	}
+
+	return w.Flush()
+}
+
+// Explain returns the option of the Traefik static configuration matching the key, the root of the configuration if empty.
+// The key can be written in any format (i.e. providers.docker.exposedByDefault, --providers.docker.exposedbydefault=true
+// or TRAEFIK_PROVIDERS_DOCKER_EXPOSEDBYDEFAULT), and the map keys are free (i.e. entryPoints.web.address).
+func Explain(key string) (*Option, error) {
+	conf := &static.Configuration{}
+	// As for the Traefik reference documentation, the configuration is filled with the default values
+	// and one entry of each map and list.
+	generator.Generate(conf)
+
+	option := &Option{Type: sectionType, value: reflect.ValueOf(conf)}
+	for _, name := range splitKey(key) {
+		child, ok := option.child(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown option %s in %s, the options are: %s", name, option.name(), strings.Join(option.childNames(), ", "))
+		}
+
+		option = child
+	}
+
+	return option, nil
+}
+
+// splitKey returns the names of the segments of a key written in any format.
+func splitKey(key string) []string {
+	key = strings.SplitN(strings.TrimLeft(key, "-"), "=", 2)[0]
+
+	separator := "."
+	if strings.HasPrefix(strings.ToUpper(key), env.DefaultNamePrefix) {
+		// As in Traefik, the environment variables set lowercase map keys.
+		key = strings.ToLower(key[len(env.DefaultNamePrefix):])
+		separator = "_"
+	}
+
+	var names []string
+	for _, name := range strings.Split(key, separator) {
+		// The indexes of the list entries are ignored (i.e. domains[0] or DOMAINS_0).
+		if i := strings.Index(name, "["); i > 0 {
+			name = name[:i]
+		}
+
+		if name == "" || separator == "_" && isNumber(name) {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func isNumber(name string) bool {
+	_, err := strconv.Atoi(name)
+	return err == nil
+}
+
+// Options returns all the options under the option: the values and the sections enabled by their presence alone.
+func (o *Option) Options() []*Option {
+	var options []*Option
+	for _, child := range o.children() {
+		if child.isLeaf() || child.AllowEmpty {
+			options = append(options, child)
+		}
+
+		options = append(options, child.Options()...)
+	}
+
+	return options
+}
+
+// YAML returns the option in a yaml file, with its default value or a placeholder of its type.
+func (o *Option) YAML() (string, error) {
+	content, err := yaml.Marshal(o.fileValue())
+	if err != nil {
+		return "", fmt.Errorf("cannot encode %s in YAML: %w", o.Key, err)
+	}
+
+	return string(content), nil
+}
+
+// TOML returns the option in a toml file, with its default value or a placeholder of its type.
+func (o *Option) TOML() (string, error) {
+	content := new(bytes.Buffer)
+	if err := toml.NewEncoder(content).Encode(o.fileValue()); err != nil {
+		return "", fmt.Errorf("cannot encode %s in TOML: %w", o.Key, err)
+	}
+
+	return content.String(), nil
+}
+
+// CLI returns the flag setting the option, with its default value or a placeholder of its type.
+func (o *Option) CLI() string {
+	var names []string
+	for _, segment := range o.path {
+		name := segment.name
+		if !segment.mapKey {
+			name = strings.ToLower(name)
+		}
+
+		if segment.list {
+			name += "[0]"
+		}
+
+		names = append(names, name)
+	}
+
+	flag := "--" + strings.Join(names, ".")
+	if !o.isLeaf() {
+		return flag + "\n"
+	}
+
+	return flag + "=" + o.exampleText() + "\n"
+}
+
+// Env returns the environment variable setting the option, with its default value or a placeholder of its type.
+func (o *Option) Env() string {
+	var names []string
+	for _, segment := range o.path {
+		name := strings.ToUpper(segment.name)
+		if segment.list {
+			name += "_0"
+		}
+
+		names = append(names, name)
+	}
+
+	value := "true"
+	if o.isLeaf() {
+		value = o.exampleText()
+	}
+
+	return env.DefaultNamePrefix + strings.Join(names, "_") + "=" + value + "\n"
+}
+
+// fileValue returns the option as a tree of maps and lists, encoded by the yaml and toml encoders.
+func (o *Option) fileValue() interface{} {
+	node := o.exampleValue()
+	for i := len(o.path) - 1; i >= 0; i-- {
+		if o.path[i].list && i < len(o.path)-1 {
+			node = []interface{}{node}
+		}
+
+		node = map[string]interface{}{o.path[i].name: node}
+	}
+
+	return node
+}
+
+// exampleText returns the default value of the option, or a placeholder of its type if there is none.
+func (o *Option) exampleText() string {
+	if o.Default == "" {
+		return "<" + o.Type + ">"
+	}
+
+	return o.Default
+}
+
+// exampleValue returns the default value of the option, typed for the yaml and toml encoders.
+func (o *Option) exampleValue() interface{} {
+	if !o.isLeaf() {
+		return map[string]interface{}{}
+	}
+
+	rType := elemType(o.value.Type())
+	if o.Default == "" {
+		if rType.Kind() == reflect.Slice {
+			return []string{"<" + typeName(rType.Elem()) + ">"}
+		}
+
+		return o.exampleText()
+	}
+
+	if rType == durationType || isTextMarshaler(rType) {
+		return o.Default
+	}
+
+	switch rType.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Slice:
+		return reflect.Indirect(o.value).Interface()
+	default:
+		return o.Default
+	}
+}
+
+func (o *Option) isLeaf() bool {
+	return o.Type != sectionType && o.Type != mapType && o.Type != listType
+}
+
+func (o *Option) name() string {
+	if o.Key == "" {
+		return "the configuration"
+	}
+
+	return o.Key
+}
+
+func (o *Option) child(name string) (*Option, bool) {
+	for _, child := range o.children() {
+		// The map entries are explained with a placeholder name.
+		if o.Type == mapType {
+			return child.withName(name), true
+		}
+
+		if strings.EqualFold(child.path[len(child.path)-1].name, name) {
+			return child, true
+		}
+	}
+
+	return nil, false
+}
+
+func (o *Option) childNames() []string {
+	var names []string
+	for _, child := range o.children() {
+		names = append(names, child.path[len(child.path)-1].name)
+	}
+
+	return names
+}
+
+func (o *Option) withName(name string) *Option {
+	path := append([]keySegment{}, o.path...)
+	path[len(path)-1].name = name
+
+	return newOption(path, o.value, o.Description, o.AllowEmpty)
+}
+
+func (o *Option) children() []*Option {
+	value := o.value
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	switch o.Type {
+	case sectionType:
+		return fieldOptions(o.path, value)
+
+	case mapType:
+		entry := value.MapIndex(reflect.ValueOf(parser.MapNamePlaceholder).Convert(value.Type().Key()))
+		if !entry.IsValid() {
+			return nil
+		}
+
+		path := append(append([]keySegment{}, o.path...), keySegment{name: parser.MapNamePlaceholder, mapKey: true})
+
+		return []*Option{newOption(path, entry, o.Description, false)}
+
+	case listType:
+		if value.Len() == 0 {
+			return nil
+		}
+
+		// The options of the list entries are explained with the first one.
+		path := append([]keySegment{}, o.path...)
+		path[len(path)-1].list = true
+
+		return fieldOptions(path, reflect.Indirect(value.Index(0)))
+
+	default:
+		return nil
+	}
+}
+
+// fieldOptions returns the options of the fields of a section.
+func fieldOptions(path []keySegment, value reflect.Value) []*Option {
+	var options []*Option
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" || field.Tag.Get(parser.TagLabel) == "-" || field.Tag.Get(parser.TagDescription) == "-" {
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		// The embedded structs fields are promoted to the parent, as in the configuration files.
+		if field.Anonymous && name == "" {
+			if embedded := reflect.Indirect(value.Field(i)); embedded.Kind() == reflect.Struct {
+				options = append(options, fieldOptions(path, embedded)...)
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldPath := append(append([]keySegment{}, path...), keySegment{name: name})
+		allowEmpty := field.Tag.Get(parser.TagLabel) == parser.TagLabelAllowEmpty
+
+		options = append(options, newOption(fieldPath, value.Field(i), field.Tag.Get(parser.TagDescription), allowEmpty))
+	}
+
+	return options
+}
+
+func newOption(path []keySegment, value reflect.Value, description string, allowEmpty bool) *Option {
+	names := make([]string, 0, len(path))
+	for _, segment := range path {
+		names = append(names, segment.name)
+	}
+
+	option := &Option{
+		Key:         strings.Join(names, "."),
+		Type:        typeName(value.Type()),
+		Description: description,
+		AllowEmpty:  allowEmpty,
+		path:        path,
+		value:       value,
+	}
+
+	if option.isLeaf() {
+		option.Default = defaultValue(value)
+	}
+
+	return option
+}
+
+func typeName(rType reflect.Type) string {
+	rType = elemType(rType)
+
+	if rType == durationType {
+		return "duration"
+	}
+
+	if isTextMarshaler(rType) {
+		return "string"
+	}
+
+	switch rType.Kind() {
+	case reflect.Struct:
+		return sectionType
+	case reflect.Map:
+		return mapType
+	case reflect.Slice, reflect.Array:
+		if elemType(rType.Elem()).Kind() == reflect.Struct && !isTextMarshaler(elemType(rType.Elem())) {
+			return listType
+		}
+
+		return "[]" + typeName(rType.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	default:
+		return rType.Kind().String()
+	}
+}
+
+// defaultValue returns the value of an option, as written in the CLI flags.
+func defaultValue(value reflect.Value) string {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Type() == durationType {
+		if value.Int() == 0 {
+			return ""
+		}
+
+		return ptypes.Duration(value.Int()).String()
+	}
+
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return ""
+		}
+
+		return string(text)
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		values := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			values = append(values, defaultValue(value.Index(i)))
+		}
+
+		return strings.Join(values, ",")
+	default:
+		return ""
+	}
+}
+
+func elemType(rType reflect.Type) reflect.Type {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	return rType
+}
+
+func isTextMarshaler(rType reflect.Type) bool {
+	return rType.Implements(textMarshalerType) || reflect.PtrTo(rType).Implements(textMarshalerType)
+}
+
+func withDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+// indent indents each line of the text, for the spellings printed under their format.
+func indent(text string) string {
+	lines := strings.Split(strings.TrimRightFunc(text, unicode.IsSpace), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCmd(t *testing.T) {
+	t.Parallel()
+	output := new(bytes.Buffer)
+	err := ExplainCmd("providers.docker.exposedByDefault", output)
+	require.NoError(t, err)
+
+	expected := `Key:          providers.docker.exposedByDefault
+Type:         bool
+Default:      true
+Description:  Expose containers by default.
+
+YAML:
+  providers:
+    docker:
+      exposedByDefault: true
+
+TOML:
+  [providers]
+    [providers.docker]
+      exposedByDefault = true
+
+CLI:
+  --providers.docker.exposedbydefault=true
+
+Env:
+  TRAEFIK_PROVIDERS_DOCKER_EXPOSEDBYDEFAULT=true
+`
+	assert.Equal(t, expected, output.String())
+}
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		desc     string
+		key      string
+		expected Option
+		cli      string
+		env      string
+		yaml     string
+	}{
+		{
+			desc: "duration",
+			key:  "providers.docker.swarmModeRefreshSeconds",
+			expected: Option{
+				Key:         "providers.docker.swarmModeRefreshSeconds",
+				Type:        "duration",
+				Default:     "15s",
+				Description: "Polling interval for swarm mode.",
+			},
+			cli:  "--providers.docker.swarmmoderefreshseconds=15s\n",
+			env:  "TRAEFIK_PROVIDERS_DOCKER_SWARMMODEREFRESHSECONDS=15s\n",
+			yaml: "providers:\n  docker:\n    swarmModeRefreshSeconds: 15s\n",
+		},
+		{
+			desc: "cli flag",
+			key:  "--providers.docker.exposedbydefault=false",
+			expected: Option{
+				Key:         "providers.docker.exposedByDefault",
+				Type:        "bool",
+				Default:     "true",
+				Description: "Expose containers by default.",
+			},
+			cli:  "--providers.docker.exposedbydefault=true\n",
+			env:  "TRAEFIK_PROVIDERS_DOCKER_EXPOSEDBYDEFAULT=true\n",
+			yaml: "providers:\n  docker:\n    exposedByDefault: true\n",
+		},
+		{
+			desc: "map entry",
+			key:  "entryPoints.webSecure.address",
+			expected: Option{
+				Key:         "entryPoints.webSecure.address",
+				Type:        "string",
+				Description: "Entry point address.",
+			},
+			cli:  "--entrypoints.webSecure.address=<string>\n",
+			env:  "TRAEFIK_ENTRYPOINTS_WEBSECURE_ADDRESS=<string>\n",
+			yaml: "entryPoints:\n  webSecure:\n    address: <string>\n",
+		},
+		{
+			desc: "environment variable",
+			key:  "TRAEFIK_ENTRYPOINTS_WEB_HTTP_TLS_DOMAINS_0_SANS",
+			expected: Option{
+				Key:         "entryPoints.web.http.tls.domains.sans",
+				Type:        "[]string",
+				Description: "Subject alternative names.",
+			},
+			cli:  "--entrypoints.web.http.tls.domains[0].sans=<[]string>\n",
+			env:  "TRAEFIK_ENTRYPOINTS_WEB_HTTP_TLS_DOMAINS_0_SANS=<[]string>\n",
+			yaml: "entryPoints:\n  web:\n    http:\n      tls:\n        domains:\n        - sans:\n          - <string>\n",
+		},
+		{
+			desc: "list",
+			key:  "metrics.prometheus.buckets",
+			expected: Option{
+				Key:         "metrics.prometheus.buckets",
+				Type:        "[]float",
+				Default:     "0.1,0.3,1.2,5",
+				Description: "Buckets for latency metrics.",
+			},
+			cli:  "--metrics.prometheus.buckets=0.1,0.3,1.2,5\n",
+			env:  "TRAEFIK_METRICS_PROMETHEUS_BUCKETS=0.1,0.3,1.2,5\n",
+			yaml: "metrics:\n  prometheus:\n    buckets:\n    - 0.1\n    - 0.3\n    - 1.2\n    - 5\n",
+		},
+		{
+			desc: "embedded struct",
+			key:  "providers.etcd.rootKey",
+			expected: Option{
+				Key:         "providers.etcd.rootKey",
+				Type:        "string",
+				Default:     "traefik",
+				Description: "Root key used for KV store",
+			},
+			cli:  "--providers.etcd.rootkey=traefik\n",
+			env:  "TRAEFIK_PROVIDERS_ETCD_ROOTKEY=traefik\n",
+			yaml: "providers:\n  etcd:\n    rootKey: traefik\n",
+		},
+		{
+			desc: "section enabled by its presence",
+			key:  "providers.docker",
+			expected: Option{
+				Key:         "providers.docker",
+				Type:        "section",
+				Description: "Enable Docker backend with default settings.",
+				AllowEmpty:  true,
+			},
+			cli:  "--providers.docker\n",
+			env:  "TRAEFIK_PROVIDERS_DOCKER=true\n",
+			yaml: "providers:\n  docker: {}\n",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			option, err := Explain(test.key)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected.Key, option.Key)
+			assert.Equal(t, test.expected.Type, option.Type)
+			assert.Equal(t, test.expected.Default, option.Default)
+			assert.Equal(t, test.expected.Description, option.Description)
+			assert.Equal(t, test.expected.AllowEmpty, option.AllowEmpty)
+
+			assert.Equal(t, test.cli, option.CLI())
+			assert.Equal(t, test.env, option.Env())
+
+			yamlSpelling, err := option.YAML()
+			require.NoError(t, err)
+			assert.Equal(t, test.yaml, yamlSpelling)
+		})
+	}
+}
+
+func TestExplainSection(t *testing.T) {
+	t.Parallel()
+	option, err := Explain("providers.docker")
+	require.NoError(t, err)
+
+	var keys []string
+	for _, child := range option.Options() {
+		keys = append(keys, child.Key)
+	}
+
+	assert.Contains(t, keys, "providers.docker.exposedByDefault")
+	assert.Contains(t, keys, "providers.docker.endpoint")
+	assert.Contains(t, keys, "providers.docker.tls.insecureSkipVerify")
+	assert.NotContains(t, keys, "providers.docker.tls")
+
+	option, err = Explain("entryPoints")
+	require.NoError(t, err)
+	assert.Equal(t, "map", option.Type)
+	assert.Equal(t, "entryPoints.<name>.address", option.Options()[0].Key)
+
+	option, err = Explain("")
+	require.NoError(t, err)
+	assert.Empty(t, option.Key)
+	assert.Greater(t, len(option.Options()), 100)
+}
+
+func TestExplainUnknownKey(t *testing.T) {
+	t.Parallel()
+	_, err := Explain("providers.dockr.exposedByDefault")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown option dockr in providers")
+	assert.Contains(t, err.Error(), "docker")
+}
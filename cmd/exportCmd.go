@@ -4,43 +4,79 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+
+	"github.com/jbdoumenjou/baeker/pkg/export"
+	"github.com/jbdoumenjou/baeker/pkg/importer"
+	"github.com/traefik/traefik/v2/pkg/config/static"
 )
 
-// ExportCmd Exports a yml static configuration file to standard output with a specified format.
-func ExportCmd(input io.Reader, to string) error {
-	// TODO: add other source type
-	conf, err := ImportYaml(input)
+// ExportOptions holds the options of the export command.
+type ExportOptions struct {
+	export.Options
+
+	// From is the source format.
+	From string
+	// To is the output format.
+	To string
+	// Strict reports the unknown keys of the yaml and toml files as errors.
+	Strict bool
+}
+
+// ExportCmd Exports a static configuration file to standard output with a specified format.
+func ExportCmd(input io.Reader, opts ExportOptions) error {
+	conf, err := importConf(input, opts.From, opts.Strict)
 	if err != nil {
 		return fmt.Errorf("cannot import source file:%w", err)
 	}
 
-	switch to {
-	case "cli":
-		err := ExportCLI(conf, os.Stdout)
-		if err != nil {
-			return fmt.Errorf("cannot export to cli format:%w", err)
-		}
-	case "toml":
-		err := ExportToml(conf, os.Stdout)
-		if err != nil {
-			return fmt.Errorf("cannot export to toml format:%w", err)
-		}
-	case "yml", "yaml":
-		err := ExportYaml(conf, os.Stdout)
-		if err != nil {
-			return fmt.Errorf("cannot export to yaml format:%w", err)
-		}
-	case "docker":
-		err := ExportDocker(conf, "./cmd/docker-compose-tpl.yml", os.Stdout)
-		if err != nil {
-			return fmt.Errorf("cannot export to docker format:%w", err)
-		}
-	case "kubernetes", "crd", "k8s":
-		err := ExportKubernetes(conf, "./cmd/traefik-lb-svc-tpl.yml", os.Stdout)
-		if err != nil {
-			return fmt.Errorf("cannot export to kubernetes format:%w", err)
-		}
+	return ExportConfCmd(conf, opts)
+}
+
+// ExportConfCmd Exports a static configuration to standard output with a specified format.
+// The format is looked up in the export.Formats registry.
+// A CLI style can only be set with the cli format, and a template with the template formats.
+func ExportConfCmd(conf *static.Configuration, opts ExportOptions) error {
+	format, ok := export.Formats.Get(opts.To)
+	if !ok {
+		return fmt.Errorf("unsupported output format: %s", opts.To)
+	}
+
+	if opts.Style != "" && format.Name != "cli" {
+		return fmt.Errorf("the %s style only applies to the cli output format, not to %s", opts.Style, format.Name)
+	}
+
+	if opts.Template != "" && !format.Template {
+		return fmt.Errorf("the template %s only applies to the template output formats (i.e. docker, kubernetes), not to %s", opts.Template, format.Name)
+	}
+
+	err := format.Exporter.Export(conf, opts.Options, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("cannot export to %s format:%w", format.Name, err)
 	}
 
 	return nil
 }
+
+// DefaultTemplateDirs returns the directories where the user templates are discovered:
+// the user configuration directory (~/.config/baeker/templates) and the project directory (.baeker/templates).
+func DefaultTemplateDirs() []string {
+	var dirs []string
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configDir = filepath.Join(home, ".config")
+		}
+	}
+
+	if configDir != "" {
+		dirs = append(dirs, filepath.Join(configDir, "baeker", "templates"))
+	}
+
+	return append(dirs, filepath.Join(".baeker", "templates"))
+}
+
+func importConf(input io.Reader, from string, strict bool) (*static.Configuration, error) {
+	return importer.Formats.Import(from, input, importer.Options{Strict: strict})
+}
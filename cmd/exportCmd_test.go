@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jbdoumenjou/baeker/pkg/export"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+func TestExportConfCmdStyleOnlyAppliesToCLI(t *testing.T) {
+	t.Parallel()
+	opts := ExportOptions{
+		Options: export.Options{Style: export.CLIStyleJSON},
+		To:      "yaml",
+	}
+
+	err := ExportConfCmd(&static.Configuration{}, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the json style only applies to the cli output format")
+}
+
+func TestExportConfCmdTemplateOnlyAppliesToTemplateFormats(t *testing.T) {
+	t.Parallel()
+	opts := ExportOptions{
+		Options: export.Options{Template: "my-compose-tpl.yml"},
+		To:      "yaml",
+	}
+
+	err := ExportConfCmd(&static.Configuration{}, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the template my-compose-tpl.yml only applies to the template output formats")
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"strings"
+
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+const (
+	jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+	traefikModule   = "github.com/traefik/traefik/v2"
+)
+
+var (
+	durationType      = reflect.TypeOf(ptypes.Duration(0))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// knownPatterns are the patterns of the string options, indexed by their path.
+// They replace an enum when Traefik accepts the values in any case.
+var knownPatterns = map[string]string{
+	"log.level": caseInsensitivePattern("DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC"),
+}
+
+// knownEnums are the known values of the string options, indexed by their path.
+// The "*" segment matches any map key (entry point name, resolver name, ...).
+var knownEnums = map[string][]string{
+	"log.format":                                        {"common", "json"},
+	"accessLog.format":                                  {"common", "json"},
+	"accessLog.fields.defaultMode":                      {"keep", "drop", "redact"},
+	"accessLog.fields.names.*":                          {"keep", "drop", "redact"},
+	"accessLog.fields.headers.defaultMode":              {"keep", "drop", "redact"},
+	"accessLog.fields.headers.names.*":                  {"keep", "drop", "redact"},
+	"entryPoints.*.http.redirections.entryPoint.scheme": {"http", "https"},
+	"certificatesResolvers.*.acme.keyType":              {"EC256", "EC384", "RSA2048", "RSA4096", "RSA8192"},
+	"tracing.jaeger.samplingType":                       {"const", "probabilistic", "rateLimiting"},
+	"tracing.jaeger.propagation":                        {"jaeger", "b3"},
+	"tracing.instana.logLevel":                          {"error", "warn", "info", "debug"},
+}
+
+// jsonSchema is a JSON Schema (draft-07) node.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+}
+
+// ExportSchema exports the JSON Schema of the Traefik static configuration.
+func ExportSchema(output io.Writer) error {
+	schema := newSchemaGenerator().generate(reflect.TypeOf(static.Configuration{}), nil)
+	schema.Schema = jsonSchemaDraft
+	schema.Title = "Traefik static configuration"
+	if version := traefikVersion(); version != "" {
+		schema.Title += " " + version
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(schema); err != nil {
+		return fmt.Errorf("cannot encode the static configuration schema: %w", err)
+	}
+
+	return nil
+}
+
+// traefikVersion returns the version of the Traefik module baeker is built against, if available.
+func traefikVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path != traefikModule {
+			continue
+		}
+
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+
+		return dep.Version
+	}
+
+	return ""
+}
+
+type schemaGenerator struct {
+	// inProgress holds the struct types being generated, to stop on recursive types.
+	inProgress map[reflect.Type]bool
+}
+
+func newSchemaGenerator() schemaGenerator {
+	return schemaGenerator{inProgress: make(map[reflect.Type]bool)}
+}
+
+func (g schemaGenerator) generate(rType reflect.Type, path []string) *jsonSchema {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	if rType == durationType {
+		// Durations are either a string ("10s") or a number of seconds.
+		return &jsonSchema{Type: []string{"string", "integer"}}
+	}
+
+	if rType.Implements(textMarshalerType) || reflect.PtrTo(rType).Implements(textMarshalerType) {
+		return &jsonSchema{Type: "string"}
+	}
+
+	switch rType.Kind() {
+	case reflect.Struct:
+		return g.generateStruct(rType, path)
+	case reflect.Map:
+		return &jsonSchema{
+			Type:                 "object",
+			AdditionalProperties: g.generate(rType.Elem(), append(path, "*")),
+		}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: "array", Items: g.generate(rType.Elem(), path)}
+	case reflect.String:
+		key := strings.Join(path, ".")
+		return &jsonSchema{Type: "string", Enum: knownEnums[key], Pattern: knownPatterns[key]}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	default:
+		return &jsonSchema{}
+	}
+}
+
+func (g schemaGenerator) generateStruct(rType reflect.Type, path []string) *jsonSchema {
+	if g.inProgress[rType] {
+		return &jsonSchema{Type: "object"}
+	}
+
+	g.inProgress[rType] = true
+	defer delete(g.inProgress, rType)
+
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		// The embedded structs fields are promoted to the parent, as encoding/json does.
+		if field.Anonymous && name == "" {
+			embedded := g.generate(field.Type, path)
+			for key, value := range embedded.Properties {
+				schema.Properties[key] = value
+			}
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := g.generate(field.Type, append(path, name))
+		fieldSchema.Description = field.Tag.Get("description")
+
+		// Options like `providers.docker` can be enabled with an empty value.
+		if fieldSchema.Type == "object" && field.Tag.Get("file") == "allowEmpty" {
+			fieldSchema.Type = []string{"object", "null"}
+		}
+
+		schema.Properties[name] = fieldSchema
+	}
+
+	return schema
+}
+
+func jsonName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// caseInsensitivePattern returns a pattern matching the values in any case.
+// The JSON Schema patterns have no flag, so each letter is matched by a class of both cases (i.e. [Dd]).
+func caseInsensitivePattern(values ...string) string {
+	alternatives := make([]string, 0, len(values))
+	for _, value := range values {
+		var alternative strings.Builder
+		for _, r := range value {
+			lower, upper := strings.ToLower(string(r)), strings.ToUpper(string(r))
+			if lower == upper {
+				alternative.WriteString(regexp.QuoteMeta(string(r)))
+				continue
+			}
+
+			alternative.WriteString("[" + upper + lower + "]")
+		}
+		alternatives = append(alternatives, alternative.String())
+	}
+
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSchema(t *testing.T) {
+	t.Parallel()
+	exportedSchema := new(bytes.Buffer)
+	err := ExportSchema(exportedSchema)
+	require.NoError(t, err)
+
+	schema := &jsonSchema{}
+	err = json.Unmarshal(exportedSchema.Bytes(), schema)
+	require.NoError(t, err)
+
+	assert.Equal(t, jsonSchemaDraft, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, false, schema.AdditionalProperties)
+
+	entryPoints := schema.Properties["entryPoints"]
+	require.NotNil(t, entryPoints)
+	assert.Equal(t, "Entry points definition.", entryPoints.Description)
+
+	entryPoint, ok := entryPoints.AdditionalProperties.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "string", entryPoint["properties"].(map[string]interface{})["address"].(map[string]interface{})["type"])
+
+	log := schema.Properties["log"]
+	require.NotNil(t, log)
+	assert.Equal(t, []interface{}{"object", "null"}, log.Type)
+	assert.Empty(t, log.Properties["level"].Enum)
+	levelPattern := regexp.MustCompile(log.Properties["level"].Pattern)
+	assert.True(t, levelPattern.MatchString("DEBUG"))
+	assert.True(t, levelPattern.MatchString("Debug"))
+	assert.False(t, levelPattern.MatchString("verbose"))
+	assert.Equal(t, []string{"common", "json"}, log.Properties["format"].Enum)
+
+	docker := schema.Properties["providers"].Properties["docker"]
+	require.NotNil(t, docker)
+	assert.Equal(t, []interface{}{"object", "null"}, docker.Type)
+	assert.Equal(t, "boolean", docker.Properties["exposedByDefault"].Type)
+	assert.Equal(t, []interface{}{"string", "integer"}, docker.Properties["swarmModeRefreshSeconds"].Type)
+
+	api := schema.Properties["api"]
+	require.NotNil(t, api)
+	assert.NotContains(t, api.Properties, "DashboardAssets")
+}
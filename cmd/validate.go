@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// Severity is the severity of a configuration problem.
+type Severity string
+
+// Severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is an issue found in a static configuration.
+type Problem struct {
+	// Path is the path of the option in the configuration (i.e. entryPoints.web.address).
+	Path     string
+	Severity Severity
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.Severity, p.Path, p.Message)
+}
+
+// HasErrors returns true if at least one of the problems is an error.
+func HasErrors(problems []Problem) bool {
+	for _, problem := range problems {
+		if problem.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks the semantic of a static configuration and returns the problems found.
+func Validate(conf *static.Configuration) []Problem {
+	var problems []Problem
+
+	problems = append(problems, validateEntryPoints(conf)...)
+	problems = append(problems, validateProviders(conf)...)
+
+	return problems
+}
+
+type listener struct {
+	entryPoint string
+	host       string
+	port       string
+	protocol   string
+}
+
+func validateEntryPoints(conf *static.Configuration) []Problem {
+	var problems []Problem
+
+	names := make([]string, 0, len(conf.EntryPoints))
+	for name := range conf.EntryPoints {
+		names = append(names, name)
+	}
+	// To keep the result consistent.
+	sort.Strings(names)
+
+	var listeners []listener
+	for _, name := range names {
+		entryPoint := conf.EntryPoints[name]
+		if entryPoint == nil {
+			continue
+		}
+
+		path := "entryPoints." + name
+
+		ln, problem := validateAddress(name, entryPoint)
+		if problem != nil {
+			problems = append(problems, *problem)
+		} else {
+			for _, other := range listeners {
+				if other.port == ln.port && other.protocol == ln.protocol &&
+					(other.host == ln.host || other.host == "" || ln.host == "") {
+					problems = append(problems, Problem{
+						Path:     path + ".address",
+						Severity: SeverityError,
+						Message:  fmt.Sprintf("port %s/%s is already used by the entry point %q", ln.port, ln.protocol, other.entryPoint),
+					})
+				}
+			}
+			listeners = append(listeners, ln)
+		}
+
+		if entryPoint.HTTP.Redirections != nil && entryPoint.HTTP.Redirections.EntryPoint != nil {
+			redirection := entryPoint.HTTP.Redirections.EntryPoint
+			if !isRedirectionTarget(conf, redirection.To) {
+				problems = append(problems, Problem{
+					Path:     path + ".http.redirections.entryPoint.to",
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("the redirection targets the unknown entry point %q", redirection.To),
+				})
+			} else if isInsecureHTTPSTarget(conf, redirection) {
+				problems = append(problems, Problem{
+					Path:     path + ".http.redirections.entryPoint.to",
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("the https redirection targets the entry point %q which has no TLS configuration", redirection.To),
+				})
+			}
+		}
+
+		if entryPoint.HTTP.TLS != nil && entryPoint.HTTP.TLS.CertResolver != "" {
+			resolver := entryPoint.HTTP.TLS.CertResolver
+			if _, ok := conf.CertificatesResolvers[resolver]; !ok {
+				problems = append(problems, Problem{
+					Path:     path + ".http.tls.certResolver",
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("the certificate resolver %q is not defined", resolver),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+func validateAddress(name string, entryPoint *static.EntryPoint) (listener, *Problem) {
+	problem := &Problem{
+		Path:     "entryPoints." + name + ".address",
+		Severity: SeverityError,
+	}
+
+	protocol, err := entryPoint.GetProtocol()
+	if err != nil {
+		problem.Message = fmt.Sprintf("malformed address %q: %v", entryPoint.Address, err)
+		return listener{}, problem
+	}
+
+	host, port, err := net.SplitHostPort(entryPoint.GetAddress())
+	if err != nil {
+		problem.Message = fmt.Sprintf("malformed address %q: %v", entryPoint.Address, err)
+		return listener{}, problem
+	}
+
+	if number, err := strconv.Atoi(port); err != nil || number < 0 || number > 65535 {
+		problem.Message = fmt.Sprintf("malformed address %q: invalid port %q", entryPoint.Address, port)
+		return listener{}, problem
+	}
+
+	return listener{entryPoint: name, host: host, port: port, protocol: protocol}, nil
+}
+
+// isRedirectionTarget checks if the target of a redirection is an entry point name or a port (i.e. ":443").
+func isRedirectionTarget(conf *static.Configuration, to string) bool {
+	if _, ok := conf.EntryPoints[to]; ok {
+		return true
+	}
+
+	if !strings.HasPrefix(to, ":") {
+		return false
+	}
+
+	_, err := strconv.Atoi(to[1:])
+
+	return err == nil
+}
+
+// isInsecureHTTPSTarget checks if an https redirection targets an entry point without TLS.
+// The routers can still enable TLS on the entry point, hence the warning.
+func isInsecureHTTPSTarget(conf *static.Configuration, redirection *static.RedirectEntryPoint) bool {
+	if redirection.Scheme != "" && redirection.Scheme != "https" {
+		return false
+	}
+
+	target, ok := conf.EntryPoints[redirection.To]
+
+	return ok && target != nil && target.HTTP.TLS == nil
+}
+
+func validateProviders(conf *static.Configuration) []Problem {
+	var problems []Problem
+
+	if conf.Providers == nil {
+		return problems
+	}
+
+	if conf.Providers.File != nil && conf.Providers.File.Filename == "" && conf.Providers.File.Directory == "" {
+		problems = append(problems, Problem{
+			Path:     "providers.file",
+			Severity: SeverityError,
+			Message:  "either the filename or the directory must be set",
+		})
+	}
+
+	return problems
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ValidateCmd Validates a static configuration file and prints the problems found to standard output.
+// In strict mode, the unknown keys of the yaml and toml files are reported as errors.
+func ValidateCmd(input io.Reader, from string, strict bool) error {
+	conf, err := importConf(input, from, strict)
+	if err != nil {
+		return fmt.Errorf("cannot import source file:%w", err)
+	}
+
+	problems := Validate(conf)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	if HasErrors(problems) {
+		return errors.New("the configuration is not valid")
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("The configuration is valid.")
+	}
+
+	return nil
+}
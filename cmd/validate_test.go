@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/acme"
+	"github.com/traefik/traefik/v2/pkg/provider/file"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		conf        *static.Configuration
+		expected    []Problem
+	}{
+		{
+			description: "empty",
+			conf:        &static.Configuration{},
+		},
+		{
+			description: "valid configuration",
+			conf: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"web": {
+						Address: ":80",
+						HTTP: static.HTTPConfig{
+							Redirections: &static.Redirections{
+								EntryPoint: &static.RedirectEntryPoint{To: "websecure"},
+							},
+						},
+					},
+					"websecure": {
+						Address: ":443",
+						HTTP: static.HTTPConfig{
+							TLS: &static.TLSConfig{CertResolver: "le"},
+						},
+					},
+					"dns": {Address: ":443/udp"},
+				},
+				CertificatesResolvers: map[string]static.CertificateResolver{
+					"le": {ACME: &acme.Configuration{}},
+				},
+				Providers: &static.Providers{
+					File: &file.Provider{Directory: "/conf"},
+				},
+			},
+		},
+		{
+			description: "malformed addresses",
+			conf: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"a": {Address: "8000"},
+					"b": {Address: ":http"},
+					"c": {Address: ":8000/sctp"},
+				},
+			},
+			expected: []Problem{
+				{Path: "entryPoints.a.address", Severity: SeverityError, Message: `malformed address "8000": address 8000: missing port in address`},
+				{Path: "entryPoints.b.address", Severity: SeverityError, Message: `malformed address ":http": invalid port "http"`},
+				{Path: "entryPoints.c.address", Severity: SeverityError, Message: `malformed address ":8000/sctp": invalid protocol: sctp`},
+			},
+		},
+		{
+			description: "duplicate ports",
+			conf: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"web":   {Address: ":80"},
+					"web2":  {Address: "127.0.0.1:80"},
+					"other": {Address: "10.0.0.1:80"},
+				},
+			},
+			expected: []Problem{
+				{Path: "entryPoints.web.address", Severity: SeverityError, Message: `port 80/tcp is already used by the entry point "other"`},
+				{Path: "entryPoints.web2.address", Severity: SeverityError, Message: `port 80/tcp is already used by the entry point "web"`},
+			},
+		},
+		{
+			description: "unknown references",
+			conf: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"web": {
+						Address: ":80",
+						HTTP: static.HTTPConfig{
+							Redirections: &static.Redirections{
+								EntryPoint: &static.RedirectEntryPoint{To: "websecure"},
+							},
+							TLS: &static.TLSConfig{CertResolver: "le"},
+						},
+					},
+				},
+			},
+			expected: []Problem{
+				{Path: "entryPoints.web.http.redirections.entryPoint.to", Severity: SeverityError, Message: `the redirection targets the unknown entry point "websecure"`},
+				{Path: "entryPoints.web.http.tls.certResolver", Severity: SeverityError, Message: `the certificate resolver "le" is not defined`},
+			},
+		},
+		{
+			description: "https redirection to an entry point without TLS",
+			conf: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"web": {
+						Address: ":80",
+						HTTP: static.HTTPConfig{
+							Redirections: &static.Redirections{
+								EntryPoint: &static.RedirectEntryPoint{To: "websecure", Scheme: "https"},
+							},
+						},
+					},
+					"websecure": {Address: ":443"},
+				},
+			},
+			expected: []Problem{
+				{Path: "entryPoints.web.http.redirections.entryPoint.to", Severity: SeverityWarning, Message: `the https redirection targets the entry point "websecure" which has no TLS configuration`},
+			},
+		},
+		{
+			description: "file provider without file nor directory",
+			conf: &static.Configuration{
+				Providers: &static.Providers{
+					File: &file.Provider{},
+				},
+			},
+			expected: []Problem{
+				{Path: "providers.file", Severity: SeverityError, Message: "either the filename or the directory must be set"},
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			problems := Validate(test.conf)
+			assert.Equal(t, test.expected, problems)
+
+			var hasErrors bool
+			for _, problem := range test.expected {
+				hasErrors = hasErrors || problem.Severity == SeverityError
+			}
+			assert.Equal(t, hasErrors, HasErrors(problems))
+		})
+	}
+}
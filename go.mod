@@ -1,6 +1,6 @@
 module github.com/jbdoumenjou/baeker
 
-go 1.15
+go 1.16
 
 require (
 	github.com/AlecAivazis/survey/v2 v2.2.3
@@ -10,8 +10,9 @@ require (
 	github.com/stretchr/testify v1.6.1
 	github.com/traefik/paerser v0.1.1
 	github.com/traefik/traefik/v2 v2.3.4
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	gopkg.in/yaml.v2 v2.3.0
-	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
 
 // Docker v19.03.6
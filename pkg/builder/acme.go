@@ -0,0 +1,102 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/acme"
+)
+
+// Let's Encrypt CA servers.
+const (
+	CAServerProduction = "https://acme-v02.api.letsencrypt.org/directory"
+	CAServerStaging    = "https://acme-staging-v02.api.letsencrypt.org/directory"
+)
+
+// ACMEOption configures an ACME certificate resolver added by the builder.
+type ACMEOption func(conf *acme.Configuration)
+
+// WithCAServer uses the given CA server instead of the Let's Encrypt production one.
+func WithCAServer(caServer string) ACMEOption {
+	return func(conf *acme.Configuration) {
+		conf.CAServer = caServer
+	}
+}
+
+// WithStaging uses the Let's Encrypt staging CA server, to test the configuration without hitting the rate limits.
+func WithStaging() ACMEOption {
+	return WithCAServer(CAServerStaging)
+}
+
+// WithKeyType sets the type of the certificates private key (EC256, EC384, RSA2048, RSA4096, RSA8192).
+func WithKeyType(keyType string) ACMEOption {
+	return func(conf *acme.Configuration) {
+		conf.KeyType = keyType
+	}
+}
+
+// WithHTTPChallenge uses the HTTP-01 challenge, on the given entry point which must be reachable on the port 80.
+func WithHTTPChallenge(entryPoint string) ACMEOption {
+	return func(conf *acme.Configuration) {
+		conf.HTTPChallenge = &acme.HTTPChallenge{EntryPoint: entryPoint}
+	}
+}
+
+// WithTLSChallenge uses the TLS-ALPN-01 challenge, which requires Traefik to be reachable on the port 443.
+func WithTLSChallenge() ACMEOption {
+	return func(conf *acme.Configuration) {
+		conf.TLSChallenge = &acme.TLSChallenge{}
+	}
+}
+
+// WithDNSChallenge uses the DNS-01 challenge with the given DNS provider (i.e. cloudflare, route53).
+// The credentials of the provider are read from environment variables by Traefik.
+func WithDNSChallenge(provider string, resolvers ...string) ACMEOption {
+	return func(conf *acme.Configuration) {
+		conf.DNSChallenge = &acme.DNSChallenge{
+			Provider:  provider,
+			Resolvers: resolvers,
+		}
+	}
+}
+
+// AddACMEResolver adds an ACME certificate resolver to the current configuration.
+// The storage is the path of the file where the certificates are stored, Traefik uses acme.json when empty.
+// A challenge option is required.
+func (s *StaticConfBuilder) AddACMEResolver(name, email, storage string, opts ...ACMEOption) (*StaticConfBuilder, error) {
+	if _, ok := s.conf.CertificatesResolvers[name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrCertResolverExists, name)
+	}
+
+	conf := &acme.Configuration{}
+	conf.SetDefaults()
+	conf.Email = email
+
+	if storage != "" {
+		conf.Storage = storage
+	}
+
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	if conf.HTTPChallenge == nil && conf.TLSChallenge == nil && conf.DNSChallenge == nil {
+		return nil, fmt.Errorf("%w: %s", ErrChallengeMissing, name)
+	}
+
+	if s.conf.CertificatesResolvers == nil {
+		s.conf.CertificatesResolvers = make(map[string]static.CertificateResolver)
+	}
+
+	s.conf.CertificatesResolvers[name] = static.CertificateResolver{ACME: conf}
+
+	return s, nil
+}
+
+// WithACMEResolver adds an ACME certificate resolver to the current configuration.
+// The storage is the path of the file where the certificates are stored, Traefik uses acme.json when empty.
+// A challenge option is required.
+func (s *StaticConfBuilder) WithACMEResolver(name, email, storage string, opts ...ACMEOption) *StaticConfBuilder {
+	_, err := s.AddACMEResolver(name, email, storage, opts...)
+	return s.collect(err)
+}
@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/acme"
+)
+
+func TestACMEResolver(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithACMEResolver("le", "admin@example.com", "/letsencrypt/acme.json", WithHTTPChallenge("web")).
+		WithACMEResolver("le-staging", "admin@example.com", "", WithStaging(), WithTLSChallenge()).
+		WithACMEResolver("dns", "admin@example.com", "", WithKeyType("EC256"), WithDNSChallenge("cloudflare", "1.1.1.1:53")).
+		Build()
+	require.NoError(t, err)
+
+	expected := map[string]static.CertificateResolver{
+		"le": {ACME: &acme.Configuration{
+			Email:         "admin@example.com",
+			CAServer:      CAServerProduction,
+			Storage:       "/letsencrypt/acme.json",
+			KeyType:       "RSA4096",
+			HTTPChallenge: &acme.HTTPChallenge{EntryPoint: "web"},
+		}},
+		"le-staging": {ACME: &acme.Configuration{
+			Email:        "admin@example.com",
+			CAServer:     CAServerStaging,
+			Storage:      "acme.json",
+			KeyType:      "RSA4096",
+			TLSChallenge: &acme.TLSChallenge{},
+		}},
+		"dns": {ACME: &acme.Configuration{
+			Email:        "admin@example.com",
+			CAServer:     CAServerProduction,
+			Storage:      "acme.json",
+			KeyType:      "EC256",
+			DNSChallenge: &acme.DNSChallenge{Provider: "cloudflare", Resolvers: []string{"1.1.1.1:53"}},
+		}},
+	}
+
+	assert.Equal(t, expected, configuration.CertificatesResolvers)
+}
+
+func TestACMEResolverErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewStaticConfBuilder().
+		WithACMEResolver("le", "admin@example.com", "", WithTLSChallenge()).
+		WithACMEResolver("le", "admin@example.com", "", WithTLSChallenge()).
+		WithACMEResolver("nochallenge", "admin@example.com", "").
+		Build()
+
+	assert.True(t, errors.Is(err, ErrCertResolverExists))
+	assert.True(t, errors.Is(err, ErrChallengeMissing))
+	assert.EqualError(t, err, `cannot build the static configuration, 2 error(s):
+  certificate resolver already exists: le
+  ACME challenge missing: nochallenge`)
+}
@@ -0,0 +1,64 @@
+package builder
+
+import (
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// APIOption configures the API added by the builder.
+type APIOption func(api *static.API)
+
+// WithDashboard enables the dashboard.
+// The dashboard is only reachable through a router to the api@internal service, unless the API is insecure.
+func WithDashboard() APIOption {
+	return func(api *static.API) {
+		api.Dashboard = true
+	}
+}
+
+// WithoutDashboard disables the dashboard, which Traefik enables by default with the API.
+func WithoutDashboard() APIOption {
+	return func(api *static.API) {
+		api.Dashboard = false
+	}
+}
+
+// WithAPIDebug enables the debug endpoints of the API.
+func WithAPIDebug() APIOption {
+	return func(api *static.API) {
+		api.Debug = true
+	}
+}
+
+// WithInsecureAPI exposes the API and the dashboard on the traefik entry point (port 8080), without any authentication.
+// It must not be used in production.
+func WithInsecureAPI() APIOption {
+	return func(api *static.API) {
+		api.Insecure = true
+	}
+}
+
+// AddAPI adds the API to the current configuration, with the dashboard unless WithoutDashboard is given.
+// The API is secure by default: it has to be exposed by a router to the api@internal service.
+func (s *StaticConfBuilder) AddAPI(opts ...APIOption) (*StaticConfBuilder, error) {
+	if s.conf.API != nil {
+		return nil, ErrAPIExists
+	}
+
+	api := &static.API{}
+	api.SetDefaults()
+
+	for _, opt := range opts {
+		opt(api)
+	}
+
+	s.conf.API = api
+
+	return s, nil
+}
+
+// WithAPI adds the API to the current configuration, with the dashboard unless WithoutDashboard is given.
+// The API is secure by default: it has to be exposed by a router to the api@internal service.
+func (s *StaticConfBuilder) WithAPI(opts ...APIOption) *StaticConfBuilder {
+	_, err := s.AddAPI(opts...)
+	return s.collect(err)
+}
@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+func TestAPI(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc     string
+		opts     []APIOption
+		expected *static.API
+	}{
+		{
+			desc:     "secure API",
+			expected: &static.API{Dashboard: true},
+		},
+		{
+			desc:     "secure API without dashboard",
+			opts:     []APIOption{WithoutDashboard()},
+			expected: &static.API{},
+		},
+		{
+			desc:     "secure dashboard",
+			opts:     []APIOption{WithDashboard()},
+			expected: &static.API{Dashboard: true},
+		},
+		{
+			desc:     "insecure dashboard with debug",
+			opts:     []APIOption{WithDashboard(), WithInsecureAPI(), WithAPIDebug()},
+			expected: &static.API{Dashboard: true, Insecure: true, Debug: true},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			configuration, err := NewStaticConfBuilder().WithAPI(test.opts...).Build()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, configuration.API)
+		})
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewStaticConfBuilder().
+		WithAPI(WithDashboard()).
+		WithAPI().
+		Build()
+
+	assert.True(t, errors.Is(err, ErrAPIExists))
+	assert.EqualError(t, err, `cannot build the static configuration, 1 error(s):
+  API already exists`)
+}
@@ -0,0 +1,269 @@
+// Package builder builds a Traefik static configuration step by step.
+//
+// The providers are the ones of the Traefik version this module depends on (v2.3).
+// The Kubernetes Gateway provider, introduced by Traefik v2.4, is not supported until the dependency is upgraded.
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/docker"
+	"github.com/traefik/traefik/v2/pkg/provider/file"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd"
+)
+
+var (
+	// ErrProviderExists is returned when a provider is added twice.
+	ErrProviderExists = errors.New("provider already exists")
+	// ErrEntryPointExists is returned when an entry point name is already used.
+	ErrEntryPointExists = errors.New("entry point already exists")
+	// ErrCertResolverExists is returned when a certificate resolver name is already used.
+	ErrCertResolverExists = errors.New("certificate resolver already exists")
+	// ErrChallengeMissing is returned when an ACME certificate resolver has no challenge.
+	ErrChallengeMissing = errors.New("ACME challenge missing")
+	// ErrAPIExists is returned when the API is added twice.
+	ErrAPIExists = errors.New("API already exists")
+	// ErrMetricsExists is returned when a metrics backend is added twice.
+	ErrMetricsExists = errors.New("metrics backend already exists")
+	// ErrTracingExists is returned when the tracing is added twice.
+	ErrTracingExists = errors.New("tracing already exists")
+	// ErrTracingBackend is returned when the tracing has no backend or several ones.
+	ErrTracingBackend = errors.New("exactly one tracing backend is required")
+	// ErrLogExists is returned when the Traefik log is added twice.
+	ErrLogExists = errors.New("log already exists")
+	// ErrAccessLogExists is returned when the access log is added twice.
+	ErrAccessLogExists = errors.New("access log already exists")
+)
+
+// StaticConfBuilder store build configuration.
+// The Add methods report their error immediately,
+// while the With methods collect the errors, to be reported all together by Build.
+type StaticConfBuilder struct {
+	conf *static.Configuration
+	errs []error
+}
+
+// BuildError holds all the errors collected by the With methods of a StaticConfBuilder.
+type BuildError struct {
+	Errors []error
+}
+
+func (e *BuildError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("cannot build the static configuration, %d error(s):", len(e.Errors)))
+	for _, err := range e.Errors {
+		sb.WriteString("\n  " + err.Error())
+	}
+
+	return sb.String()
+}
+
+// Is reports whether one of the errors matches the target.
+func (e *BuildError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewStaticConfBuilder creates a StaticConfBuilder.
+// Like Traefik, the builder sets the default values of the sections it adds, which the given options override.
+func NewStaticConfBuilder() *StaticConfBuilder {
+	return &StaticConfBuilder{
+		conf: &static.Configuration{
+			Providers:   &static.Providers{},
+			EntryPoints: static.EntryPoints{},
+		},
+	}
+}
+
+// GetConfiguration returns the current configuration.
+func (s *StaticConfBuilder) GetConfiguration() *static.Configuration {
+	return s.conf
+}
+
+// AddKubernetesProvider adds Kubernetes CRD Provider to the current configuration.
+func (s *StaticConfBuilder) AddKubernetesProvider() (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.KubernetesCRD != nil {
+		return nil, fmt.Errorf("the KubernetesCRD %w", ErrProviderExists)
+	}
+
+	// The Kubernetes CRD provider has no default values.
+	s.conf.Providers.KubernetesCRD = &crd.Provider{}
+
+	return s, nil
+}
+
+// AddDockerProvider adds Docker Provider to the current configuration.
+func (s *StaticConfBuilder) AddDockerProvider() (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Docker != nil {
+		return nil, fmt.Errorf("the Docker %w", ErrProviderExists)
+	}
+
+	provider := &docker.Provider{}
+	provider.SetDefaults()
+
+	s.conf.Providers.Docker = provider
+
+	return s, nil
+}
+
+// AddFileProvider adds File Provider to the current configuration.
+func (s *StaticConfBuilder) AddFileProvider(directory string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.File != nil {
+		return nil, fmt.Errorf("the File %w", ErrProviderExists)
+	}
+
+	provider := &file.Provider{}
+	provider.SetDefaults()
+	provider.Directory = directory
+
+	s.conf.Providers.File = provider
+
+	return s, nil
+}
+
+// AddEntryPoint adds an entrypoint to the current configuration, configured by the options.
+func (s *StaticConfBuilder) AddEntryPoint(name string, address string, opts ...EntryPointOption) (*StaticConfBuilder, error) {
+	if _, ok := s.conf.EntryPoints[name]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrEntryPointExists, name)
+	}
+
+	ep := &static.EntryPoint{}
+	ep.SetDefaults()
+	ep.Address = address
+
+	for _, opt := range opts {
+		opt(ep)
+	}
+
+	s.conf.EntryPoints[name] = ep
+
+	return s, nil
+}
+
+// WithKubernetesProvider adds Kubernetes CRD Provider to the current configuration.
+func (s *StaticConfBuilder) WithKubernetesProvider() *StaticConfBuilder {
+	_, err := s.AddKubernetesProvider()
+	return s.collect(err)
+}
+
+// WithDockerProvider adds Docker Provider to the current configuration.
+func (s *StaticConfBuilder) WithDockerProvider() *StaticConfBuilder {
+	_, err := s.AddDockerProvider()
+	return s.collect(err)
+}
+
+// WithFileProvider adds File Provider to the current configuration.
+func (s *StaticConfBuilder) WithFileProvider(directory string) *StaticConfBuilder {
+	_, err := s.AddFileProvider(directory)
+	return s.collect(err)
+}
+
+// WithEntryPoint adds an entrypoint to the current configuration, configured by the options.
+func (s *StaticConfBuilder) WithEntryPoint(name string, address string, opts ...EntryPointOption) *StaticConfBuilder {
+	_, err := s.AddEntryPoint(name, address, opts...)
+	return s.collect(err)
+}
+
+// Build returns a copy of the configuration, or a BuildError with all the errors collected by the With methods.
+func (s *StaticConfBuilder) Build() (*static.Configuration, error) {
+	if len(s.errs) > 0 {
+		return nil, &BuildError{Errors: append([]error(nil), s.errs...)}
+	}
+
+	return deepCopy(reflect.ValueOf(s.conf)).Interface().(*static.Configuration), nil
+}
+
+// Clone returns a builder with a deep copy of the configuration and of the collected errors,
+// to branch a base configuration without sharing it.
+func (s *StaticConfBuilder) Clone() *StaticConfBuilder {
+	return &StaticConfBuilder{
+		conf: deepCopy(reflect.ValueOf(s.conf)).Interface().(*static.Configuration),
+		errs: append([]error(nil), s.errs...),
+	}
+}
+
+func (s *StaticConfBuilder) collect(err error) *StaticConfBuilder {
+	if err != nil {
+		s.errs = append(s.errs, err)
+	}
+
+	return s
+}
+
+// deepCopy copies the value, following the pointers, maps, slices and interfaces.
+// The unexported struct fields are copied as is.
+func deepCopy(src reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+
+		dst := reflect.New(src.Type().Elem())
+		dst.Elem().Set(deepCopy(src.Elem()))
+
+		return dst
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(deepCopy(src.Elem()))
+
+		return dst
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				dst.Field(i).Set(deepCopy(src.Field(i)))
+			}
+		}
+
+		return dst
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+
+		return dst
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i)))
+		}
+
+		return dst
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(deepCopy(src.Index(i)))
+		}
+
+		return dst
+	default:
+		return src
+	}
+}
@@ -0,0 +1,190 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+func TestAddKubernetesProvider(t *testing.T) {
+	t.Parallel()
+	builder, err := NewStaticConfBuilder().AddKubernetesProvider()
+	require.NoError(t, err)
+
+	configuration := builder.GetConfiguration()
+	assert.NotNil(t, configuration.Providers)
+	assert.NotNil(t, configuration.Providers.KubernetesCRD)
+}
+
+func TestAddDockerProvider(t *testing.T) {
+	t.Parallel()
+	builder, err := NewStaticConfBuilder().AddDockerProvider()
+	require.NoError(t, err)
+
+	configuration := builder.GetConfiguration()
+	assert.NotNil(t, configuration.Providers)
+	assert.NotNil(t, configuration.Providers.Docker)
+}
+
+func TestAddFileProvider(t *testing.T) {
+	t.Parallel()
+	directory := "/foo/bar"
+	builder, err := NewStaticConfBuilder().AddFileProvider(directory)
+	require.NoError(t, err)
+
+	configuration := builder.GetConfiguration()
+	assert.NotNil(t, configuration.Providers)
+	assert.NotNil(t, configuration.Providers.File)
+	assert.Equal(t, directory, configuration.Providers.File.Directory)
+}
+
+func TestAddEntryPoint(t *testing.T) {
+	t.Parallel()
+	builder, err := NewStaticConfBuilder().AddEntryPoint("web", ":8000")
+	require.NoError(t, err)
+	_, err = builder.AddEntryPoint("websecure", ":8443")
+	require.NoError(t, err)
+
+	configuration := builder.GetConfiguration()
+	require.Equal(t, 2, len(configuration.EntryPoints))
+
+	ep, ok := configuration.EntryPoints["web"]
+	require.True(t, ok)
+	assert.Equal(t, newDefaultEntryPoint(":8000"), ep)
+
+	ep, ok = configuration.EntryPoints["websecure"]
+	require.True(t, ok)
+	assert.Equal(t, newDefaultEntryPoint(":8443"), ep)
+}
+
+func TestBuilderErrors(t *testing.T) {
+	t.Parallel()
+	builder, err := NewStaticConfBuilder().AddDockerProvider()
+	require.NoError(t, err)
+
+	_, err = builder.AddDockerProvider()
+	assert.True(t, errors.Is(err, ErrProviderExists))
+	assert.EqualError(t, err, "the Docker provider already exists")
+
+	builder, err = builder.AddEntryPoint("web", ":8000")
+	require.NoError(t, err)
+
+	_, err = builder.AddEntryPoint("web", ":8080")
+	assert.True(t, errors.Is(err, ErrEntryPointExists))
+	assert.EqualError(t, err, "entry point already exists: web")
+}
+
+func TestBuilderPointerSemantics(t *testing.T) {
+	t.Parallel()
+	builder := NewStaticConfBuilder()
+
+	next, err := builder.AddDockerProvider()
+	require.NoError(t, err)
+	assert.Same(t, builder, next)
+
+	next, err = next.AddEntryPoint("web", ":8000")
+	require.NoError(t, err)
+	assert.Same(t, builder, next)
+}
+
+func TestFluentBuilder(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithDockerProvider().
+		WithEntryPoint("web", ":8000").
+		WithEntryPoint("websecure", ":8443").
+		Build()
+	require.NoError(t, err)
+
+	assert.NotNil(t, configuration.Providers.Docker)
+	assert.Equal(t, static.EntryPoints{
+		"web":       newDefaultEntryPoint(":8000"),
+		"websecure": newDefaultEntryPoint(":8443"),
+	}, configuration.EntryPoints)
+}
+
+func TestFluentBuilderErrors(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithDockerProvider().
+		WithDockerProvider().
+		WithEntryPoint("web", ":8000").
+		WithEntryPoint("web", ":8080").
+		Build()
+	require.Error(t, err)
+	assert.Nil(t, configuration)
+
+	var buildErr *BuildError
+	require.True(t, errors.As(err, &buildErr))
+	assert.Len(t, buildErr.Errors, 2)
+	assert.True(t, errors.Is(err, ErrProviderExists))
+	assert.True(t, errors.Is(err, ErrEntryPointExists))
+	assert.EqualError(t, err, `cannot build the static configuration, 2 error(s):
+  the Docker provider already exists
+  entry point already exists: web`)
+}
+
+func TestBuildReturnsACopy(t *testing.T) {
+	t.Parallel()
+	builder := NewStaticConfBuilder().WithEntryPoint("web", ":8000")
+
+	configuration, err := builder.Build()
+	require.NoError(t, err)
+
+	builder.WithEntryPoint("websecure", ":8443")
+	configuration.EntryPoints["web"].Address = ":80"
+
+	assert.Len(t, configuration.EntryPoints, 1)
+	assert.Equal(t, ":8000", builder.GetConfiguration().EntryPoints["web"].Address)
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+	base := NewStaticConfBuilder().WithEntryPoint("web", ":8000")
+
+	dockerConf, err := base.Clone().WithDockerProvider().WithEntryPoint("websecure", ":8443").Build()
+	require.NoError(t, err)
+
+	fileConf, err := base.Clone().WithFileProvider("conf").Build()
+	require.NoError(t, err)
+
+	baseConf, err := base.Build()
+	require.NoError(t, err)
+
+	assert.NotNil(t, dockerConf.Providers.Docker)
+	assert.Nil(t, dockerConf.Providers.File)
+	assert.Len(t, dockerConf.EntryPoints, 2)
+
+	assert.Nil(t, fileConf.Providers.Docker)
+	assert.NotNil(t, fileConf.Providers.File)
+	assert.Len(t, fileConf.EntryPoints, 1)
+
+	assert.Nil(t, baseConf.Providers.Docker)
+	assert.Nil(t, baseConf.Providers.File)
+	assert.Len(t, baseConf.EntryPoints, 1)
+
+	// The entry points are not shared either.
+	dockerConf.EntryPoints["web"].Address = ":80"
+	assert.Equal(t, ":8000", fileConf.EntryPoints["web"].Address)
+	assert.Equal(t, ":8000", base.GetConfiguration().EntryPoints["web"].Address)
+}
+
+func TestCloneKeepsErrors(t *testing.T) {
+	t.Parallel()
+	base := NewStaticConfBuilder().WithDockerProvider().WithDockerProvider()
+
+	_, err := base.Clone().WithEntryPoint("web", ":8000").Build()
+	assert.True(t, errors.Is(err, ErrProviderExists))
+}
+
+// newDefaultEntryPoint returns an entry point with the default values set by Traefik.
+func newDefaultEntryPoint(address string) *static.EntryPoint {
+	ep := &static.EntryPoint{}
+	ep.SetDefaults()
+	ep.Address = address
+
+	return ep
+}
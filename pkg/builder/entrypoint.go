@@ -0,0 +1,85 @@
+package builder
+
+import (
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// EntryPointOption configures an entry point added by the builder.
+type EntryPointOption func(ep *static.EntryPoint)
+
+// WithRedirection permanently redirects the HTTP requests of the entry point to the `to` entry point, with the https scheme.
+func WithRedirection(to string) EntryPointOption {
+	return func(ep *static.EntryPoint) {
+		redirection := &static.RedirectEntryPoint{}
+		redirection.SetDefaults()
+		redirection.To = to
+
+		ep.HTTP.Redirections = &static.Redirections{EntryPoint: redirection}
+	}
+}
+
+// WithTLS enables TLS by default for the routers of the entry point.
+// The certificates are generated by the certResolver for the given domains, both are optional.
+func WithTLS(certResolver string, domains ...types.Domain) EntryPointOption {
+	return func(ep *static.EntryPoint) {
+		ep.HTTP.TLS = &static.TLSConfig{
+			CertResolver: certResolver,
+			Domains:      domains,
+		}
+	}
+}
+
+// WithForwardedHeaders trusts the forwarded headers (X-Forwarded-*) from the trustedIPs, or from everywhere when insecure.
+func WithForwardedHeaders(insecure bool, trustedIPs ...string) EntryPointOption {
+	return func(ep *static.EntryPoint) {
+		ep.ForwardedHeaders = &static.ForwardedHeaders{
+			Insecure:   insecure,
+			TrustedIPs: trustedIPs,
+		}
+	}
+}
+
+// WithProxyProtocol accepts the PROXY protocol header from the trustedIPs, or from everywhere when insecure.
+func WithProxyProtocol(insecure bool, trustedIPs ...string) EntryPointOption {
+	return func(ep *static.EntryPoint) {
+		ep.ProxyProtocol = &static.ProxyProtocol{
+			Insecure:   insecure,
+			TrustedIPs: trustedIPs,
+		}
+	}
+}
+
+// WithRespondingTimeouts sets the timeouts for the incoming requests, zero means no timeout.
+func WithRespondingTimeouts(read, write, idle time.Duration) EntryPointOption {
+	return func(ep *static.EntryPoint) {
+		if ep.Transport == nil {
+			ep.Transport = &static.EntryPointsTransport{}
+			ep.Transport.SetDefaults()
+		}
+
+		ep.Transport.RespondingTimeouts = &static.RespondingTimeouts{
+			ReadTimeout:  ptypes.Duration(read),
+			WriteTimeout: ptypes.Duration(write),
+			IdleTimeout:  ptypes.Duration(idle),
+		}
+	}
+}
+
+// WithLifeCycle sets the timeouts of the graceful shutdown.
+func WithLifeCycle(requestAcceptGraceTimeout, graceTimeOut time.Duration) EntryPointOption {
+	return func(ep *static.EntryPoint) {
+		if ep.Transport == nil {
+			ep.Transport = &static.EntryPointsTransport{}
+			ep.Transport.SetDefaults()
+		}
+
+		ep.Transport.LifeCycle = &static.LifeCycle{
+			RequestAcceptGraceTimeout: ptypes.Duration(requestAcceptGraceTimeout),
+			GraceTimeOut:              ptypes.Duration(graceTimeOut),
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestEntryPointOptions(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithEntryPoint("web", ":8000",
+			WithRedirection("websecure"),
+			WithForwardedHeaders(false, "10.0.0.0/8", "192.168.0.1"),
+		).
+		WithEntryPoint("websecure", ":8443",
+			WithTLS("le", types.Domain{Main: "example.com", SANs: []string{"*.example.com"}}),
+			WithProxyProtocol(true),
+			WithRespondingTimeouts(10*time.Second, 0, 3*time.Minute),
+			WithLifeCycle(time.Second, 20*time.Second),
+		).
+		Build()
+	require.NoError(t, err)
+
+	web := newDefaultEntryPoint(":8000")
+	web.HTTP.Redirections = &static.Redirections{
+		EntryPoint: &static.RedirectEntryPoint{To: "websecure", Scheme: "https", Permanent: true, Priority: math.MaxInt32},
+	}
+	web.ForwardedHeaders = &static.ForwardedHeaders{TrustedIPs: []string{"10.0.0.0/8", "192.168.0.1"}}
+
+	websecure := newDefaultEntryPoint(":8443")
+	websecure.HTTP.TLS = &static.TLSConfig{
+		CertResolver: "le",
+		Domains:      []types.Domain{{Main: "example.com", SANs: []string{"*.example.com"}}},
+	}
+	websecure.ProxyProtocol = &static.ProxyProtocol{Insecure: true}
+	websecure.Transport = &static.EntryPointsTransport{
+		RespondingTimeouts: &static.RespondingTimeouts{
+			ReadTimeout: ptypes.Duration(10 * time.Second),
+			IdleTimeout: ptypes.Duration(3 * time.Minute),
+		},
+		LifeCycle: &static.LifeCycle{
+			RequestAcceptGraceTimeout: ptypes.Duration(time.Second),
+			GraceTimeOut:              ptypes.Duration(20 * time.Second),
+		},
+	}
+
+	expected := static.EntryPoints{"web": web, "websecure": websecure}
+
+	assert.Equal(t, expected, configuration.EntryPoints)
+}
@@ -0,0 +1,164 @@
+package builder
+
+import (
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// AccessLogOption configures the access log added by the builder.
+type AccessLogOption func(accessLog *types.AccessLog)
+
+// WithBufferingSize processes the given number of access log lines in a buffered way.
+func WithBufferingSize(size int64) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		accessLog.BufferingSize = size
+	}
+}
+
+// WithStatusCodes keeps only the access logs with a status code in the given ranges (i.e. 200, 300-302).
+func WithStatusCodes(codes ...string) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		filters(accessLog).StatusCodes = codes
+	}
+}
+
+// WithRetryAttempts keeps the access logs of the requests retried at least once, whatever the other filters.
+func WithRetryAttempts() AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		filters(accessLog).RetryAttempts = true
+	}
+}
+
+// WithMinDuration keeps the access logs of the requests longer than the given duration, whatever the other filters.
+func WithMinDuration(duration time.Duration) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		filters(accessLog).MinDuration = ptypes.Duration(duration)
+	}
+}
+
+// WithDefaultFieldMode keeps or drops the fields of the access logs (keep or drop).
+func WithDefaultFieldMode(mode string) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		fields(accessLog).DefaultMode = mode
+	}
+}
+
+// WithFieldMode keeps or drops a field of the access logs (i.e. ClientUsername), whatever the default mode.
+func WithFieldMode(name, mode string) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		f := fields(accessLog)
+		if f.Names == nil {
+			f.Names = make(map[string]string)
+		}
+
+		f.Names[name] = mode
+	}
+}
+
+// WithDefaultHeaderMode keeps, drops or redacts the headers of the access logs (keep, drop or redact).
+func WithDefaultHeaderMode(mode string) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		headers(accessLog).DefaultMode = mode
+	}
+}
+
+// WithHeaderMode keeps, drops or redacts a header of the access logs (i.e. Authorization), whatever the default mode.
+func WithHeaderMode(name, mode string) AccessLogOption {
+	return func(accessLog *types.AccessLog) {
+		h := headers(accessLog)
+		if h.Names == nil {
+			h.Names = make(map[string]string)
+		}
+
+		h.Names[name] = mode
+	}
+}
+
+// AddLog adds the Traefik log to the current configuration.
+// Traefik uses the ERROR level, the common format and the standard output when the options are empty.
+func (s *StaticConfBuilder) AddLog(level, format, filePath string) (*StaticConfBuilder, error) {
+	if s.conf.Log != nil {
+		return nil, ErrLogExists
+	}
+
+	log := &types.TraefikLog{FilePath: filePath}
+	log.SetDefaults()
+
+	if level != "" {
+		log.Level = level
+	}
+
+	if format != "" {
+		log.Format = format
+	}
+
+	s.conf.Log = log
+
+	return s, nil
+}
+
+// AddAccessLog adds the access log to the current configuration.
+// Traefik uses the common format and the standard output when the options are empty.
+func (s *StaticConfBuilder) AddAccessLog(format, filePath string, opts ...AccessLogOption) (*StaticConfBuilder, error) {
+	if s.conf.AccessLog != nil {
+		return nil, ErrAccessLogExists
+	}
+
+	accessLog := &types.AccessLog{}
+	accessLog.SetDefaults()
+	accessLog.FilePath = filePath
+
+	if format != "" {
+		accessLog.Format = format
+	}
+
+	for _, opt := range opts {
+		opt(accessLog)
+	}
+
+	s.conf.AccessLog = accessLog
+
+	return s, nil
+}
+
+// WithLog adds the Traefik log to the current configuration.
+// Traefik uses the ERROR level, the common format and the standard output when the options are empty.
+func (s *StaticConfBuilder) WithLog(level, format, filePath string) *StaticConfBuilder {
+	_, err := s.AddLog(level, format, filePath)
+	return s.collect(err)
+}
+
+// WithAccessLog adds the access log to the current configuration.
+// Traefik uses the common format and the standard output when the options are empty.
+func (s *StaticConfBuilder) WithAccessLog(format, filePath string, opts ...AccessLogOption) *StaticConfBuilder {
+	_, err := s.AddAccessLog(format, filePath, opts...)
+	return s.collect(err)
+}
+
+func filters(accessLog *types.AccessLog) *types.AccessLogFilters {
+	if accessLog.Filters == nil {
+		accessLog.Filters = &types.AccessLogFilters{}
+	}
+
+	return accessLog.Filters
+}
+
+func fields(accessLog *types.AccessLog) *types.AccessLogFields {
+	if accessLog.Fields == nil {
+		accessLog.Fields = &types.AccessLogFields{}
+		accessLog.Fields.SetDefaults()
+	}
+
+	return accessLog.Fields
+}
+
+func headers(accessLog *types.AccessLog) *types.FieldHeaders {
+	f := fields(accessLog)
+	if f.Headers == nil {
+		f.Headers = &types.FieldHeaders{}
+	}
+
+	return f.Headers
+}
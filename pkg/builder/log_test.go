@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestLog(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithLog("DEBUG", "json", "/var/log/traefik/traefik.log").
+		WithAccessLog("json", "/var/log/traefik/access.log",
+			WithBufferingSize(100),
+			WithStatusCodes("400-499", "500-599"),
+			WithRetryAttempts(),
+			WithMinDuration(10*time.Millisecond),
+			WithDefaultFieldMode(types.AccessLogKeep),
+			WithFieldMode("ClientUsername", types.AccessLogDrop),
+			WithDefaultHeaderMode(types.AccessLogDrop),
+			WithHeaderMode("User-Agent", types.AccessLogKeep),
+			WithHeaderMode("Authorization", types.AccessLogRedact),
+		).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, &types.TraefikLog{Level: "DEBUG", Format: "json", FilePath: "/var/log/traefik/traefik.log"}, configuration.Log)
+
+	expected := &types.AccessLog{
+		FilePath:      "/var/log/traefik/access.log",
+		Format:        "json",
+		BufferingSize: 100,
+		Filters: &types.AccessLogFilters{
+			StatusCodes:   []string{"400-499", "500-599"},
+			RetryAttempts: true,
+			MinDuration:   ptypes.Duration(10 * time.Millisecond),
+		},
+		Fields: &types.AccessLogFields{
+			DefaultMode: types.AccessLogKeep,
+			Names:       map[string]string{"ClientUsername": types.AccessLogDrop},
+			Headers: &types.FieldHeaders{
+				DefaultMode: types.AccessLogDrop,
+				Names:       map[string]string{"User-Agent": types.AccessLogKeep, "Authorization": types.AccessLogRedact},
+			},
+		},
+	}
+	assert.Equal(t, expected, configuration.AccessLog)
+}
+
+func TestLogErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewStaticConfBuilder().
+		WithLog("", "", "").
+		WithLog("DEBUG", "", "").
+		WithAccessLog("", "").
+		WithAccessLog("json", "").
+		Build()
+
+	assert.True(t, errors.Is(err, ErrLogExists))
+	assert.True(t, errors.Is(err, ErrAccessLogExists))
+	assert.EqualError(t, err, `cannot build the static configuration, 2 error(s):
+  log already exists
+  access log already exists`)
+}
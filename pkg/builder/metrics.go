@@ -0,0 +1,144 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// AddPrometheusMetrics adds the Prometheus metrics to the current configuration.
+// The metrics are served on the given entry point, the traefik one (port 8080) when empty.
+// Traefik uses its default latency buckets when none are given.
+func (s *StaticConfBuilder) AddPrometheusMetrics(entryPoint string, buckets ...float64) (*StaticConfBuilder, error) {
+	if s.conf.Metrics != nil && s.conf.Metrics.Prometheus != nil {
+		return nil, fmt.Errorf("the Prometheus %w", ErrMetricsExists)
+	}
+
+	prometheus := &types.Prometheus{}
+	prometheus.SetDefaults()
+
+	if entryPoint != "" {
+		prometheus.EntryPoint = entryPoint
+	}
+
+	if len(buckets) > 0 {
+		prometheus.Buckets = buckets
+	}
+
+	s.metrics().Prometheus = prometheus
+
+	return s, nil
+}
+
+// AddDatadogMetrics adds the Datadog metrics to the current configuration.
+// Traefik uses localhost:8125 and pushes every 10s when the address and the push interval are empty.
+func (s *StaticConfBuilder) AddDatadogMetrics(address string, pushInterval time.Duration) (*StaticConfBuilder, error) {
+	if s.conf.Metrics != nil && s.conf.Metrics.Datadog != nil {
+		return nil, fmt.Errorf("the Datadog %w", ErrMetricsExists)
+	}
+
+	datadog := &types.Datadog{}
+	datadog.SetDefaults()
+
+	if address != "" {
+		datadog.Address = address
+	}
+
+	if pushInterval != 0 {
+		datadog.PushInterval = ptypes.Duration(pushInterval)
+	}
+
+	s.metrics().Datadog = datadog
+
+	return s, nil
+}
+
+// AddStatsDMetrics adds the StatsD metrics to the current configuration.
+// Traefik uses localhost:8125 and pushes every 10s when the address and the push interval are empty.
+func (s *StaticConfBuilder) AddStatsDMetrics(address string, pushInterval time.Duration) (*StaticConfBuilder, error) {
+	if s.conf.Metrics != nil && s.conf.Metrics.StatsD != nil {
+		return nil, fmt.Errorf("the StatsD %w", ErrMetricsExists)
+	}
+
+	statsD := &types.Statsd{}
+	statsD.SetDefaults()
+
+	if address != "" {
+		statsD.Address = address
+	}
+
+	if pushInterval != 0 {
+		statsD.PushInterval = ptypes.Duration(pushInterval)
+	}
+
+	s.metrics().StatsD = statsD
+
+	return s, nil
+}
+
+// AddInfluxDBMetrics adds the InfluxDB metrics to the current configuration, with the udp or http protocol.
+// Traefik uses localhost:8089 with udp and pushes every 10s when the options are empty.
+func (s *StaticConfBuilder) AddInfluxDBMetrics(address, protocol string, pushInterval time.Duration) (*StaticConfBuilder, error) {
+	if s.conf.Metrics != nil && s.conf.Metrics.InfluxDB != nil {
+		return nil, fmt.Errorf("the InfluxDB %w", ErrMetricsExists)
+	}
+
+	influxDB := &types.InfluxDB{}
+	influxDB.SetDefaults()
+
+	if address != "" {
+		influxDB.Address = address
+	}
+
+	if protocol != "" {
+		influxDB.Protocol = protocol
+	}
+
+	if pushInterval != 0 {
+		influxDB.PushInterval = ptypes.Duration(pushInterval)
+	}
+
+	s.metrics().InfluxDB = influxDB
+
+	return s, nil
+}
+
+// WithPrometheusMetrics adds the Prometheus metrics to the current configuration.
+// The metrics are served on the given entry point, the traefik one (port 8080) when empty.
+// Traefik uses its default latency buckets when none are given.
+func (s *StaticConfBuilder) WithPrometheusMetrics(entryPoint string, buckets ...float64) *StaticConfBuilder {
+	_, err := s.AddPrometheusMetrics(entryPoint, buckets...)
+	return s.collect(err)
+}
+
+// WithDatadogMetrics adds the Datadog metrics to the current configuration.
+// Traefik uses localhost:8125 and pushes every 10s when the address and the push interval are empty.
+func (s *StaticConfBuilder) WithDatadogMetrics(address string, pushInterval time.Duration) *StaticConfBuilder {
+	_, err := s.AddDatadogMetrics(address, pushInterval)
+	return s.collect(err)
+}
+
+// WithStatsDMetrics adds the StatsD metrics to the current configuration.
+// Traefik uses localhost:8125 and pushes every 10s when the address and the push interval are empty.
+func (s *StaticConfBuilder) WithStatsDMetrics(address string, pushInterval time.Duration) *StaticConfBuilder {
+	_, err := s.AddStatsDMetrics(address, pushInterval)
+	return s.collect(err)
+}
+
+// WithInfluxDBMetrics adds the InfluxDB metrics to the current configuration, with the udp or http protocol.
+// Traefik uses localhost:8089 with udp and pushes every 10s when the options are empty.
+func (s *StaticConfBuilder) WithInfluxDBMetrics(address, protocol string, pushInterval time.Duration) *StaticConfBuilder {
+	_, err := s.AddInfluxDBMetrics(address, protocol, pushInterval)
+	return s.collect(err)
+}
+
+// metrics returns the metrics section of the configuration, created when needed.
+func (s *StaticConfBuilder) metrics() *types.Metrics {
+	if s.conf.Metrics == nil {
+		s.conf.Metrics = &types.Metrics{}
+	}
+
+	return s.conf.Metrics
+}
@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithPrometheusMetrics("metrics", 0.1, 0.5, 1).
+		WithDatadogMetrics("datadog:8125", 15*time.Second).
+		WithStatsDMetrics("", 0).
+		WithInfluxDBMetrics("influxdb:8086", "http", time.Minute).
+		Build()
+	require.NoError(t, err)
+
+	expected := &types.Metrics{
+		Prometheus: &types.Prometheus{
+			EntryPoint:           "metrics",
+			Buckets:              []float64{0.1, 0.5, 1},
+			AddEntryPointsLabels: true,
+			AddServicesLabels:    true,
+		},
+		Datadog: &types.Datadog{
+			Address:              "datadog:8125",
+			PushInterval:         ptypes.Duration(15 * time.Second),
+			AddEntryPointsLabels: true,
+			AddServicesLabels:    true,
+		},
+		StatsD: &types.Statsd{
+			Address:              "localhost:8125",
+			PushInterval:         ptypes.Duration(10 * time.Second),
+			AddEntryPointsLabels: true,
+			AddServicesLabels:    true,
+			Prefix:               "traefik",
+		},
+		InfluxDB: &types.InfluxDB{
+			Address:              "influxdb:8086",
+			Protocol:             "http",
+			PushInterval:         ptypes.Duration(time.Minute),
+			AddEntryPointsLabels: true,
+			AddServicesLabels:    true,
+		},
+	}
+
+	assert.Equal(t, expected, configuration.Metrics)
+}
+
+func TestMetricsErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewStaticConfBuilder().
+		WithPrometheusMetrics("").
+		WithPrometheusMetrics("metrics").
+		WithDatadogMetrics("", 0).
+		WithDatadogMetrics("", 0).
+		Build()
+
+	assert.True(t, errors.Is(err, ErrMetricsExists))
+	assert.EqualError(t, err, `cannot build the static configuration, 2 error(s):
+  the Prometheus metrics backend already exists
+  the Datadog metrics backend already exists`)
+}
@@ -0,0 +1,304 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/provider/consulcatalog"
+	"github.com/traefik/traefik/v2/pkg/provider/ecs"
+	"github.com/traefik/traefik/v2/pkg/provider/http"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/ingress"
+	"github.com/traefik/traefik/v2/pkg/provider/kv"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/consul"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/etcd"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/redis"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/zk"
+	"github.com/traefik/traefik/v2/pkg/provider/marathon"
+	"github.com/traefik/traefik/v2/pkg/provider/rancher"
+	"github.com/traefik/traefik/v2/pkg/provider/rest"
+)
+
+// AddKubernetesIngressProvider adds Kubernetes Ingress Provider to the current configuration.
+// Only the ingresses of the given class and namespaces are watched, all of them when empty.
+func (s *StaticConfBuilder) AddKubernetesIngressProvider(ingressClass string, namespaces ...string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.KubernetesIngress != nil {
+		return nil, fmt.Errorf("the KubernetesIngress %w", ErrProviderExists)
+	}
+
+	s.conf.Providers.KubernetesIngress = &ingress.Provider{
+		IngressClass: ingressClass,
+		Namespaces:   namespaces,
+	}
+
+	return s, nil
+}
+
+// AddMarathonProvider adds Marathon Provider to the current configuration.
+// Traefik uses http://127.0.0.1:8080 when the endpoint is empty.
+func (s *StaticConfBuilder) AddMarathonProvider(endpoint string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Marathon != nil {
+		return nil, fmt.Errorf("the Marathon %w", ErrProviderExists)
+	}
+
+	provider := &marathon.Provider{}
+	provider.SetDefaults()
+
+	if endpoint != "" {
+		provider.Endpoint = endpoint
+	}
+
+	s.conf.Providers.Marathon = provider
+
+	return s, nil
+}
+
+// AddRancherProvider adds Rancher Provider to the current configuration.
+// Traefik uses the latest prefix of the Rancher metadata service when the prefix is empty.
+func (s *StaticConfBuilder) AddRancherProvider(prefix string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Rancher != nil {
+		return nil, fmt.Errorf("the Rancher %w", ErrProviderExists)
+	}
+
+	provider := &rancher.Provider{}
+	provider.SetDefaults()
+
+	if prefix != "" {
+		provider.Prefix = prefix
+	}
+
+	s.conf.Providers.Rancher = provider
+
+	return s, nil
+}
+
+// AddConsulCatalogProvider adds Consul Catalog Provider to the current configuration.
+// Traefik uses 127.0.0.1:8500 when the address is empty, and the traefik prefix for the tags when the prefix is empty.
+func (s *StaticConfBuilder) AddConsulCatalogProvider(address, prefix string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.ConsulCatalog != nil {
+		return nil, fmt.Errorf("the ConsulCatalog %w", ErrProviderExists)
+	}
+
+	provider := &consulcatalog.Provider{}
+	provider.SetDefaults()
+
+	if address != "" {
+		provider.Endpoint.Address = address
+	}
+
+	if prefix != "" {
+		provider.Prefix = prefix
+	}
+
+	s.conf.Providers.ConsulCatalog = provider
+
+	return s, nil
+}
+
+// AddECSProvider adds AWS ECS Provider to the current configuration.
+// Traefik watches the default cluster when no cluster is given.
+func (s *StaticConfBuilder) AddECSProvider(region string, clusters ...string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Ecs != nil {
+		return nil, fmt.Errorf("the ECS %w", ErrProviderExists)
+	}
+
+	provider := &ecs.Provider{}
+	provider.SetDefaults()
+	provider.Region = region
+
+	if len(clusters) > 0 {
+		provider.Clusters = clusters
+	}
+
+	s.conf.Providers.Ecs = provider
+
+	return s, nil
+}
+
+// AddConsulProvider adds Consul KV Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) AddConsulProvider(rootKey string, endpoints ...string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Consul != nil {
+		return nil, fmt.Errorf("the Consul %w", ErrProviderExists)
+	}
+
+	provider := &consul.Provider{}
+	provider.SetDefaults()
+	setKVOptions(&provider.Provider, rootKey, endpoints)
+
+	s.conf.Providers.Consul = provider
+
+	return s, nil
+}
+
+// AddEtcdProvider adds Etcd Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) AddEtcdProvider(rootKey string, endpoints ...string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Etcd != nil {
+		return nil, fmt.Errorf("the Etcd %w", ErrProviderExists)
+	}
+
+	provider := &etcd.Provider{}
+	provider.SetDefaults()
+	setKVOptions(&provider.Provider, rootKey, endpoints)
+
+	s.conf.Providers.Etcd = provider
+
+	return s, nil
+}
+
+// AddZooKeeperProvider adds ZooKeeper Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) AddZooKeeperProvider(rootKey string, endpoints ...string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.ZooKeeper != nil {
+		return nil, fmt.Errorf("the ZooKeeper %w", ErrProviderExists)
+	}
+
+	provider := &zk.Provider{}
+	provider.SetDefaults()
+	setKVOptions(&provider.Provider, rootKey, endpoints)
+
+	s.conf.Providers.ZooKeeper = provider
+
+	return s, nil
+}
+
+// AddRedisProvider adds Redis Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) AddRedisProvider(rootKey string, endpoints ...string) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Redis != nil {
+		return nil, fmt.Errorf("the Redis %w", ErrProviderExists)
+	}
+
+	provider := &redis.Provider{}
+	provider.SetDefaults()
+	setKVOptions(&provider.Provider, rootKey, endpoints)
+
+	s.conf.Providers.Redis = provider
+
+	return s, nil
+}
+
+// AddHTTPProvider adds HTTP Provider to the current configuration, polling the dynamic configuration from the endpoint.
+// Traefik polls every 5s when the poll interval is zero.
+func (s *StaticConfBuilder) AddHTTPProvider(endpoint string, pollInterval time.Duration) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.HTTP != nil {
+		return nil, fmt.Errorf("the HTTP %w", ErrProviderExists)
+	}
+
+	provider := &http.Provider{}
+	provider.SetDefaults()
+	provider.Endpoint = endpoint
+
+	if pollInterval != 0 {
+		provider.PollInterval = ptypes.Duration(pollInterval)
+	}
+
+	s.conf.Providers.HTTP = provider
+
+	return s, nil
+}
+
+// AddRestProvider adds REST Provider to the current configuration.
+// An insecure provider is exposed on the traefik entry point, without any authentication.
+func (s *StaticConfBuilder) AddRestProvider(insecure bool) (*StaticConfBuilder, error) {
+	if s.conf.Providers != nil && s.conf.Providers.Rest != nil {
+		return nil, fmt.Errorf("the Rest %w", ErrProviderExists)
+	}
+
+	provider := &rest.Provider{}
+	provider.SetDefaults()
+	provider.Insecure = insecure
+
+	s.conf.Providers.Rest = provider
+
+	return s, nil
+}
+
+// WithKubernetesIngressProvider adds Kubernetes Ingress Provider to the current configuration.
+// Only the ingresses of the given class and namespaces are watched, all of them when empty.
+func (s *StaticConfBuilder) WithKubernetesIngressProvider(ingressClass string, namespaces ...string) *StaticConfBuilder {
+	_, err := s.AddKubernetesIngressProvider(ingressClass, namespaces...)
+	return s.collect(err)
+}
+
+// WithMarathonProvider adds Marathon Provider to the current configuration.
+// Traefik uses http://127.0.0.1:8080 when the endpoint is empty.
+func (s *StaticConfBuilder) WithMarathonProvider(endpoint string) *StaticConfBuilder {
+	_, err := s.AddMarathonProvider(endpoint)
+	return s.collect(err)
+}
+
+// WithRancherProvider adds Rancher Provider to the current configuration.
+// Traefik uses the latest prefix of the Rancher metadata service when the prefix is empty.
+func (s *StaticConfBuilder) WithRancherProvider(prefix string) *StaticConfBuilder {
+	_, err := s.AddRancherProvider(prefix)
+	return s.collect(err)
+}
+
+// WithConsulCatalogProvider adds Consul Catalog Provider to the current configuration.
+// Traefik uses 127.0.0.1:8500 when the address is empty, and the traefik prefix for the tags when the prefix is empty.
+func (s *StaticConfBuilder) WithConsulCatalogProvider(address, prefix string) *StaticConfBuilder {
+	_, err := s.AddConsulCatalogProvider(address, prefix)
+	return s.collect(err)
+}
+
+// WithECSProvider adds AWS ECS Provider to the current configuration.
+// Traefik watches the default cluster when no cluster is given.
+func (s *StaticConfBuilder) WithECSProvider(region string, clusters ...string) *StaticConfBuilder {
+	_, err := s.AddECSProvider(region, clusters...)
+	return s.collect(err)
+}
+
+// WithConsulProvider adds Consul KV Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) WithConsulProvider(rootKey string, endpoints ...string) *StaticConfBuilder {
+	_, err := s.AddConsulProvider(rootKey, endpoints...)
+	return s.collect(err)
+}
+
+// WithEtcdProvider adds Etcd Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) WithEtcdProvider(rootKey string, endpoints ...string) *StaticConfBuilder {
+	_, err := s.AddEtcdProvider(rootKey, endpoints...)
+	return s.collect(err)
+}
+
+// WithZooKeeperProvider adds ZooKeeper Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) WithZooKeeperProvider(rootKey string, endpoints ...string) *StaticConfBuilder {
+	_, err := s.AddZooKeeperProvider(rootKey, endpoints...)
+	return s.collect(err)
+}
+
+// WithRedisProvider adds Redis Provider to the current configuration.
+// Traefik uses the traefik root key when it is empty.
+func (s *StaticConfBuilder) WithRedisProvider(rootKey string, endpoints ...string) *StaticConfBuilder {
+	_, err := s.AddRedisProvider(rootKey, endpoints...)
+	return s.collect(err)
+}
+
+// WithHTTPProvider adds HTTP Provider to the current configuration, polling the dynamic configuration from the endpoint.
+// Traefik polls every 5s when the poll interval is zero.
+func (s *StaticConfBuilder) WithHTTPProvider(endpoint string, pollInterval time.Duration) *StaticConfBuilder {
+	_, err := s.AddHTTPProvider(endpoint, pollInterval)
+	return s.collect(err)
+}
+
+// WithRestProvider adds REST Provider to the current configuration.
+// An insecure provider is exposed on the traefik entry point, without any authentication.
+func (s *StaticConfBuilder) WithRestProvider(insecure bool) *StaticConfBuilder {
+	_, err := s.AddRestProvider(insecure)
+	return s.collect(err)
+}
+
+// setKVOptions overrides the default root key and endpoints of a kv provider, when they are given.
+func setKVOptions(provider *kv.Provider, rootKey string, endpoints []string) {
+	if rootKey != "" {
+		provider.RootKey = rootKey
+	}
+
+	if len(endpoints) > 0 {
+		provider.Endpoints = endpoints
+	}
+}
@@ -0,0 +1,93 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/provider/docker"
+	"github.com/traefik/traefik/v2/pkg/provider/ecs"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/ingress"
+	"github.com/traefik/traefik/v2/pkg/provider/kv"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/redis"
+	"github.com/traefik/traefik/v2/pkg/provider/rest"
+)
+
+func TestProviders(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithKubernetesIngressProvider("traefik", "default", "apps").
+		WithMarathonProvider("http://marathon:8080").
+		WithRancherProvider("").
+		WithConsulCatalogProvider("consul:8500", "edge").
+		WithECSProvider("eu-west-1", "front").
+		WithConsulProvider("", "consul:8500").
+		WithEtcdProvider("etcd", "etcd:2379").
+		WithZooKeeperProvider("", "zk:2181").
+		WithRedisProvider("", "redis:6379").
+		WithHTTPProvider("http://config/dynamic", 10*time.Second).
+		WithRestProvider(true).
+		Build()
+	require.NoError(t, err)
+
+	providers := configuration.Providers
+	assert.Equal(t, &ingress.Provider{IngressClass: "traefik", Namespaces: []string{"default", "apps"}}, providers.KubernetesIngress)
+	assert.Equal(t, "http://marathon:8080", providers.Marathon.Endpoint)
+	assert.True(t, providers.Marathon.ExposedByDefault)
+	assert.Equal(t, "latest", providers.Rancher.Prefix)
+	assert.Equal(t, "consul:8500", providers.ConsulCatalog.Endpoint.Address)
+	assert.Equal(t, "edge", providers.ConsulCatalog.Prefix)
+	assert.Equal(t, "eu-west-1", providers.Ecs.Region)
+	assert.Equal(t, []string{"front"}, providers.Ecs.Clusters)
+	assert.Equal(t, kv.Provider{RootKey: "traefik", Endpoints: []string{"consul:8500"}}, providers.Consul.Provider)
+	assert.Equal(t, kv.Provider{RootKey: "etcd", Endpoints: []string{"etcd:2379"}}, providers.Etcd.Provider)
+	assert.Equal(t, kv.Provider{RootKey: "traefik", Endpoints: []string{"zk:2181"}}, providers.ZooKeeper.Provider)
+	assert.Equal(t, kv.Provider{RootKey: "traefik", Endpoints: []string{"redis:6379"}}, providers.Redis.Provider)
+	assert.Equal(t, "http://config/dynamic", providers.HTTP.Endpoint)
+	assert.Equal(t, ptypes.Duration(10*time.Second), providers.HTTP.PollInterval)
+	assert.Equal(t, &rest.Provider{Insecure: true}, providers.Rest)
+}
+
+func TestProvidersDefaults(t *testing.T) {
+	t.Parallel()
+	configuration, err := NewStaticConfBuilder().
+		WithDockerProvider().
+		WithECSProvider("").
+		WithRedisProvider("").
+		WithHTTPProvider("http://config/dynamic", 0).
+		Build()
+	require.NoError(t, err)
+
+	expectedDocker := &docker.Provider{}
+	expectedDocker.SetDefaults()
+
+	expectedECS := &ecs.Provider{}
+	expectedECS.SetDefaults()
+
+	expectedRedis := &redis.Provider{}
+	expectedRedis.SetDefaults()
+
+	providers := configuration.Providers
+	assert.Equal(t, expectedDocker, providers.Docker)
+	assert.Equal(t, expectedECS, providers.Ecs)
+	assert.Equal(t, expectedRedis, providers.Redis)
+	assert.Equal(t, ptypes.Duration(5*time.Second), providers.HTTP.PollInterval)
+}
+
+func TestProvidersErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewStaticConfBuilder().
+		WithConsulCatalogProvider("", "").
+		WithConsulCatalogProvider("consul:8500", "").
+		WithRedisProvider("").
+		WithRedisProvider("", "redis:6379").
+		Build()
+
+	assert.True(t, errors.Is(err, ErrProviderExists))
+	assert.EqualError(t, err, `cannot build the static configuration, 2 error(s):
+  the ConsulCatalog provider already exists
+  the Redis provider already exists`)
+}
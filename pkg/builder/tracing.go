@@ -0,0 +1,170 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/tracing/datadog"
+	"github.com/traefik/traefik/v2/pkg/tracing/elastic"
+	"github.com/traefik/traefik/v2/pkg/tracing/haystack"
+	"github.com/traefik/traefik/v2/pkg/tracing/instana"
+	"github.com/traefik/traefik/v2/pkg/tracing/jaeger"
+	"github.com/traefik/traefik/v2/pkg/tracing/zipkin"
+)
+
+// TracingOption configures the tracing added by the builder.
+type TracingOption func(tracing *static.Tracing)
+
+// WithJaeger sends the traces to the Jaeger agent listening on localAgentHostPort (127.0.0.1:6831 when empty),
+// with the sampling strategy served by samplingServerURL (http://localhost:5778/sampling when empty).
+func WithJaeger(localAgentHostPort, samplingServerURL string) TracingOption {
+	return func(tracing *static.Tracing) {
+		config := &jaeger.Config{}
+		config.SetDefaults()
+
+		if localAgentHostPort != "" {
+			config.LocalAgentHostPort = localAgentHostPort
+		}
+
+		if samplingServerURL != "" {
+			config.SamplingServerURL = samplingServerURL
+		}
+
+		tracing.Jaeger = config
+	}
+}
+
+// WithZipkin sends the traces to the Zipkin httpEndpoint (http://localhost:9411/api/v2/spans when empty).
+func WithZipkin(httpEndpoint string) TracingOption {
+	return func(tracing *static.Tracing) {
+		config := &zipkin.Config{}
+		config.SetDefaults()
+
+		if httpEndpoint != "" {
+			config.HTTPEndpoint = httpEndpoint
+		}
+
+		tracing.Zipkin = config
+	}
+}
+
+// WithDatadogTracing sends the traces to the Datadog agent listening on localAgentHostPort (localhost:8126 when empty).
+func WithDatadogTracing(localAgentHostPort string) TracingOption {
+	return func(tracing *static.Tracing) {
+		config := &datadog.Config{}
+		config.SetDefaults()
+
+		if localAgentHostPort != "" {
+			config.LocalAgentHostPort = localAgentHostPort
+		}
+
+		tracing.Datadog = config
+	}
+}
+
+// WithElastic sends the traces to the Elastic APM server (http://localhost:8200 when empty),
+// authenticated by the secret token if any.
+func WithElastic(serverURL, secretToken string) TracingOption {
+	return func(tracing *static.Tracing) {
+		tracing.Elastic = &elastic.Config{
+			ServerURL:   serverURL,
+			SecretToken: secretToken,
+		}
+	}
+}
+
+// WithInstana sends the traces to the Instana agent listening on localAgentHost:localAgentPort (port 42699 when zero).
+func WithInstana(localAgentHost string, localAgentPort int) TracingOption {
+	return func(tracing *static.Tracing) {
+		config := &instana.Config{}
+		config.SetDefaults()
+
+		if localAgentHost != "" {
+			config.LocalAgentHost = localAgentHost
+		}
+
+		if localAgentPort != 0 {
+			config.LocalAgentPort = localAgentPort
+		}
+
+		tracing.Instana = config
+	}
+}
+
+// WithHaystack sends the traces to the Haystack agent listening on localAgentHost:localAgentPort
+// (127.0.0.1:35000 when empty).
+func WithHaystack(localAgentHost string, localAgentPort int) TracingOption {
+	return func(tracing *static.Tracing) {
+		config := &haystack.Config{}
+		config.SetDefaults()
+
+		if localAgentHost != "" {
+			config.LocalAgentHost = localAgentHost
+		}
+
+		if localAgentPort != 0 {
+			config.LocalAgentPort = localAgentPort
+		}
+
+		tracing.Haystack = config
+	}
+}
+
+// WithSpanNameLimit truncates the span names to the given number of characters.
+func WithSpanNameLimit(limit int) TracingOption {
+	return func(tracing *static.Tracing) {
+		tracing.SpanNameLimit = limit
+	}
+}
+
+// AddTracing adds the tracing to the current configuration.
+// The service name is traefik when empty, and exactly one backend option is required.
+func (s *StaticConfBuilder) AddTracing(serviceName string, opts ...TracingOption) (*StaticConfBuilder, error) {
+	if s.conf.Tracing != nil {
+		return nil, ErrTracingExists
+	}
+
+	tracing := &static.Tracing{}
+	tracing.SetDefaults()
+
+	if serviceName != "" {
+		tracing.ServiceName = serviceName
+	}
+
+	for _, opt := range opts {
+		opt(tracing)
+	}
+
+	if backends := countTracingBackends(tracing); backends != 1 {
+		return nil, fmt.Errorf("%w, got %d", ErrTracingBackend, backends)
+	}
+
+	s.conf.Tracing = tracing
+
+	return s, nil
+}
+
+// WithTracing adds the tracing to the current configuration.
+// The service name is traefik when empty, and exactly one backend option is required.
+func (s *StaticConfBuilder) WithTracing(serviceName string, opts ...TracingOption) *StaticConfBuilder {
+	_, err := s.AddTracing(serviceName, opts...)
+	return s.collect(err)
+}
+
+func countTracingBackends(tracing *static.Tracing) int {
+	var count int
+	for _, enabled := range []bool{
+		tracing.Jaeger != nil,
+		tracing.Zipkin != nil,
+		tracing.Datadog != nil,
+		tracing.Elastic != nil,
+		tracing.Instana != nil,
+		tracing.Haystack != nil,
+	} {
+		if enabled {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,115 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/tracing/datadog"
+	"github.com/traefik/traefik/v2/pkg/tracing/elastic"
+	"github.com/traefik/traefik/v2/pkg/tracing/haystack"
+	"github.com/traefik/traefik/v2/pkg/tracing/instana"
+	"github.com/traefik/traefik/v2/pkg/tracing/jaeger"
+	"github.com/traefik/traefik/v2/pkg/tracing/zipkin"
+)
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc     string
+		opt      TracingOption
+		expected func() static.Tracing
+	}{
+		{
+			desc: "jaeger",
+			opt:  WithJaeger("jaeger:6831", "http://jaeger:5778/sampling"),
+			expected: func() static.Tracing {
+				config := &jaeger.Config{}
+				config.SetDefaults()
+				config.LocalAgentHostPort = "jaeger:6831"
+				config.SamplingServerURL = "http://jaeger:5778/sampling"
+				return static.Tracing{Jaeger: config}
+			},
+		},
+		{
+			desc: "zipkin",
+			opt:  WithZipkin("http://zipkin:9411/api/v2/spans"),
+			expected: func() static.Tracing {
+				config := &zipkin.Config{}
+				config.SetDefaults()
+				config.HTTPEndpoint = "http://zipkin:9411/api/v2/spans"
+				return static.Tracing{Zipkin: config}
+			},
+		},
+		{
+			desc: "datadog",
+			opt:  WithDatadogTracing("datadog:8126"),
+			expected: func() static.Tracing {
+				config := &datadog.Config{}
+				config.SetDefaults()
+				config.LocalAgentHostPort = "datadog:8126"
+				return static.Tracing{Datadog: config}
+			},
+		},
+		{
+			desc: "elastic",
+			opt:  WithElastic("http://apm:8200", "secret"),
+			expected: func() static.Tracing {
+				return static.Tracing{Elastic: &elastic.Config{ServerURL: "http://apm:8200", SecretToken: "secret"}}
+			},
+		},
+		{
+			desc: "instana",
+			opt:  WithInstana("instana", 42699),
+			expected: func() static.Tracing {
+				config := &instana.Config{}
+				config.SetDefaults()
+				config.LocalAgentHost = "instana"
+				return static.Tracing{Instana: config}
+			},
+		},
+		{
+			desc: "haystack",
+			opt:  WithHaystack("haystack", 35000),
+			expected: func() static.Tracing {
+				config := &haystack.Config{}
+				config.SetDefaults()
+				config.LocalAgentHost = "haystack"
+				return static.Tracing{Haystack: config}
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			configuration, err := NewStaticConfBuilder().WithTracing("proxy", test.opt, WithSpanNameLimit(64)).Build()
+			require.NoError(t, err)
+
+			expected := test.expected()
+			expected.ServiceName = "proxy"
+			expected.SpanNameLimit = 64
+			assert.Equal(t, &expected, configuration.Tracing)
+		})
+	}
+}
+
+func TestTracingErrors(t *testing.T) {
+	t.Parallel()
+	_, err := NewStaticConfBuilder().
+		WithTracing("").
+		WithTracing("", WithJaeger("", ""), WithZipkin("")).
+		WithTracing("", WithZipkin("")).
+		WithTracing("", WithZipkin("")).
+		Build()
+
+	assert.True(t, errors.Is(err, ErrTracingBackend))
+	assert.True(t, errors.Is(err, ErrTracingExists))
+	assert.EqualError(t, err, `cannot build the static configuration, 3 error(s):
+  exactly one tracing backend is required, got 0
+  exactly one tracing backend is required, got 2
+  tracing already exists`)
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"sort"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// dnsProviderEnvVars are the environment variables holding the credentials of the most common DNS providers,
+// as documented by lego (https://go-acme.github.io/lego/dns/).
+var dnsProviderEnvVars = map[string][]string{
+	"azure":        {"AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_RESOURCE_GROUP", "AZURE_SUBSCRIPTION_ID", "AZURE_TENANT_ID"},
+	"cloudflare":   {"CF_DNS_API_TOKEN"},
+	"digitalocean": {"DO_AUTH_TOKEN"},
+	"dnsimple":     {"DNSIMPLE_OAUTH_TOKEN"},
+	"duckdns":      {"DUCKDNS_TOKEN"},
+	"gandiv5":      {"GANDIV5_API_KEY"},
+	"gcloud":       {"GCE_PROJECT", "GCE_SERVICE_ACCOUNT_FILE"},
+	"godaddy":      {"GODADDY_API_KEY", "GODADDY_API_SECRET"},
+	"hetzner":      {"HETZNER_API_KEY"},
+	"linode":       {"LINODE_TOKEN"},
+	"namecheap":    {"NAMECHEAP_API_KEY", "NAMECHEAP_API_USER"},
+	"ovh":          {"OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CONSUMER_KEY", "OVH_ENDPOINT"},
+	"route53":      {"AWS_ACCESS_KEY_ID", "AWS_REGION", "AWS_SECRET_ACCESS_KEY"},
+	"vultr":        {"VULTR_API_KEY"},
+}
+
+// DNSProviders returns the DNS providers whose credentials are known, sorted by name.
+func DNSProviders() []string {
+	providers := make([]string, 0, len(dnsProviderEnvVars))
+	for provider := range dnsProviderEnvVars {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	return providers
+}
+
+// DNSProviderEnvVars returns the environment variables holding the credentials of the DNS provider,
+// or nil if the provider is unknown.
+func DNSProviderEnvVars(provider string) []string {
+	return dnsProviderEnvVars[provider]
+}
+
+// getSecretEnvVars returns the environment variables holding the credentials of the ACME DNS challenges.
+func getSecretEnvVars(conf *static.Configuration) []string {
+	envVars := make(map[string]struct{})
+	for _, resolver := range conf.CertificatesResolvers {
+		if resolver.ACME == nil || resolver.ACME.DNSChallenge == nil {
+			continue
+		}
+
+		for _, envVar := range DNSProviderEnvVars(resolver.ACME.DNSChallenge.Provider) {
+			envVars[envVar] = struct{}{}
+		}
+	}
+
+	return sortedKeys(envVars)
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// Dashboard holds the options of the router exposing the dashboard in the docker and kubernetes templates.
+// The router is only generated when the dashboard is enabled and the API is not insecure.
+type Dashboard struct {
+	// Host is the domain name of the dashboard.
+	Host string
+	// Users are the basic auth credentials, in the htpasswd format (i.e. user:$2y$05$...).
+	Users []string
+	// EntryPoint is the entry point of the router. When empty, websecure is used, or web without websecure.
+	EntryPoint string
+}
+
+// dashboardRouter is the data model of the dashboard router in the templates.
+type dashboardRouter struct {
+	Host  string
+	Users []string
+	// EntryPoint is the entry point of the router.
+	EntryPoint string
+	// TLS is true when the entry point terminates TLS.
+	TLS bool
+	// CertResolver is the certificate resolver of the entry point.
+	CertResolver string
+}
+
+// getDashboardRouter returns the router exposing the dashboard, or nil when there is no dashboard to expose.
+func getDashboardRouter(conf *static.Configuration, dashboard *Dashboard) (*dashboardRouter, error) {
+	if conf.API == nil || !conf.API.Dashboard || conf.API.Insecure || dashboard == nil || dashboard.Host == "" {
+		return nil, nil
+	}
+
+	if len(dashboard.Users) == 0 {
+		return nil, errors.New("the dashboard router needs at least one basic auth user")
+	}
+
+	router := &dashboardRouter{
+		Host:  dashboard.Host,
+		Users: dashboard.Users,
+	}
+
+	name, err := dashboardEntryPoint(conf, dashboard.EntryPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	router.EntryPoint = name
+	if tls := conf.EntryPoints[name].HTTP.TLS; tls != nil {
+		router.TLS = true
+		router.CertResolver = tls.CertResolver
+	}
+
+	return router, nil
+}
+
+// dashboardEntryPoint returns the entry point of the dashboard router: the given one, otherwise websecure or web.
+// Any other entry point may serve something else (i.e. the metrics), so it must be given explicitly.
+func dashboardEntryPoint(conf *static.Configuration, name string) (string, error) {
+	if name != "" {
+		if _, ok := conf.EntryPoints[name]; !ok {
+			return "", fmt.Errorf("the entry point %q of the dashboard router is not defined", name)
+		}
+
+		return name, nil
+	}
+
+	for _, name := range []string{"websecure", "web"} {
+		if _, ok := conf.EntryPoints[name]; ok {
+			return name, nil
+		}
+	}
+
+	return "", errors.New("there is no websecure or web entry point, the entry point of the dashboard router must be set")
+}
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/traefik/paerser/parser"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// zeroDefaults are the values Traefik uses for the options left to zero, indexed by their label key.
+// These options have no default value in their section, Traefik sets them when it loads the configuration.
+var zeroDefaults = map[string]string{
+	".Providers.ProvidersThrottleDuration":      strconv.FormatInt(int64(2*time.Second), 10),
+	".Providers.Docker.SwarmModeRefreshSeconds": strconv.FormatInt(int64(15*time.Second), 10),
+	".Providers.Rancher.RefreshSeconds":         "15",
+}
+
+// setZeroDefaults replaces the zero values of the labels by the values Traefik uses for them.
+func setZeroDefaults(labels map[string]string) {
+	for key, defaultValue := range zeroDefaults {
+		if labels[key] == "0" {
+			labels[key] = defaultValue
+		}
+	}
+}
+
+// defaultsSetter is implemented by the sections of the configuration which have default values (i.e. the providers).
+type defaultsSetter interface {
+	SetDefaults()
+}
+
+func getDefaultsLabel(conf *static.Configuration) (map[string]string, error) {
+	defaultConf := &static.Configuration{}
+	setDefaultSections(reflect.ValueOf(defaultConf).Elem(), reflect.ValueOf(conf).Elem())
+
+	labels, err := parser.Encode(defaultConf, "")
+	if err != nil {
+		return labels, fmt.Errorf("cannot encode default configuration: %w", err)
+	}
+
+	setZeroDefaults(labels)
+
+	return labels, nil
+}
+
+// setDefaultSections adds to defaultValue the sections of rValue (i.e. providers, entry points) it lacks,
+// with their options set to the values Traefik uses when they are not configured.
+// Encoding defaultValue then gives the labels of the options left to their default value, which are not exported.
+func setDefaultSections(defaultValue, rValue reflect.Value) {
+	switch rValue.Kind() {
+	case reflect.Ptr:
+		if rValue.IsNil() || rValue.Elem().Kind() != reflect.Struct {
+			return
+		}
+
+		if defaultValue.IsNil() {
+			defaultValue.Set(reflect.New(rValue.Type().Elem()))
+
+			if setter, ok := defaultValue.Interface().(defaultsSetter); ok {
+				setter.SetDefaults()
+			}
+		}
+
+		setDefaultSections(defaultValue.Elem(), rValue.Elem())
+
+	case reflect.Map:
+		if rValue.IsNil() {
+			return
+		}
+
+		if defaultValue.IsNil() {
+			defaultValue.Set(reflect.MakeMapWithSize(rValue.Type(), rValue.Len()))
+		}
+
+		for _, key := range rValue.MapKeys() {
+			// The map values are not addressable, they are set once their sections are added.
+			element := reflect.New(rValue.Type().Elem()).Elem()
+			if current := defaultValue.MapIndex(key); current.IsValid() {
+				element.Set(current)
+			}
+
+			setDefaultSections(element, rValue.MapIndex(key))
+			defaultValue.SetMapIndex(key, element)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < rValue.NumField(); i++ {
+			if rValue.Type().Field(i).PkgPath != "" {
+				continue
+			}
+
+			setDefaultSections(defaultValue.Field(i), rValue.Field(i))
+		}
+	}
+}
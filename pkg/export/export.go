@@ -0,0 +1,588 @@
+// Package export writes a Traefik static configuration to toml, yaml, json, CLI flags, environment variables or templates.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/traefik/paerser/env"
+	"github.com/traefik/paerser/parser"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"gopkg.in/yaml.v2"
+)
+
+// traefikConf is the data model of the export templates.
+type traefikConf struct {
+	// Configuration is the full static configuration.
+	Configuration *static.Configuration
+	// Labels are the non default options, in the CLI format without the leading dashes.
+	Labels []string
+	// Ports are the ports of the published entry points, which include the traefik one only with the PublishInternal option.
+	Ports []entryPoint
+	// Volumes are the directories to persist (i.e. the ACME certificates storage).
+	Volumes []volume
+	// SecretEnvVars are the environment variables to define from secrets (i.e. the DNS provider credentials).
+	SecretEnvVars []string
+	// Dashboard is the router exposing the dashboard behind a basic auth, nil when there is no such router.
+	Dashboard *dashboardRouter
+	// Prometheus describes the Prometheus metrics endpoint, nil when there is no such endpoint.
+	Prometheus *prometheusMetrics
+	// TracingCompanion is the tracing backend to run next to Traefik, nil when there is none.
+	TracingCompanion *tracingCompanion
+}
+
+var (
+	durationType   = reflect.TypeOf(ptypes.Duration(0))
+	floatSliceType = reflect.TypeOf([]float64{})
+)
+
+type entryPoint struct {
+	Name  string
+	Value string
+}
+
+// getCleanedLabels returns the labels of the configuration which are not default values, unless they are included, indexed by their key.
+// The sections enabled without any option (i.e. providers.docker) are represented by a label with an empty value.
+func getCleanedLabels(conf *static.Configuration, includeDefaults bool) (map[string]string, error) {
+	labels, err := parser.Encode(conf, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration: %w", err)
+	}
+
+	setZeroDefaults(labels)
+
+	defaultLabels := make(map[string]string)
+	if !includeDefaults {
+		defaultLabels, err = getDefaultsLabel(conf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the default labels: %w", err)
+		}
+	}
+
+	cleanedLabels := make(map[string]string)
+	for key, value := range labels {
+		if len(key) == 0 || len(value) == 0 {
+			continue
+		}
+
+		name, rType := resolveKey(key[1:])
+
+		// An empty section (i.e. tlsChallenge) is enabled by a "true" value, which is never a default.
+		defaultValue, ok := defaultLabels[key]
+		if ok && defaultValue == value && !isSection(rType) {
+			continue
+		}
+
+		cleanedLabels[name] = formatValue(value, rType)
+	}
+
+	addEmptySections(reflect.ValueOf(conf), "", cleanedLabels)
+
+	return cleanedLabels, nil
+}
+
+// addEmptySections adds the sections without any option (i.e. metrics.statsd), which are enabled by their bare flag.
+// Only the sections allowed to be empty are added, as Traefik rejects the bare flag of the other ones.
+func addEmptySections(rValue reflect.Value, prefix string, labels map[string]string) {
+	for rValue.Kind() == reflect.Ptr || rValue.Kind() == reflect.Interface {
+		if rValue.IsNil() {
+			return
+		}
+		rValue = rValue.Elem()
+	}
+
+	switch rValue.Kind() {
+	case reflect.Map:
+		for _, key := range rValue.MapKeys() {
+			addEmptySections(rValue.MapIndex(key), prefix+fmt.Sprint(key.Interface())+".", labels)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < rValue.NumField(); i++ {
+			field := rValue.Type().Field(i)
+			if field.PkgPath != "" || field.Tag.Get("label") == "-" {
+				continue
+			}
+
+			fieldValue := rValue.Field(i)
+
+			// The options of an embedded struct (i.e. the kv providers) are options of the embedding section.
+			if field.Anonymous {
+				addEmptySections(fieldValue, prefix, labels)
+				continue
+			}
+
+			name := prefix + strings.ToLower(field.Name)
+			addEmptySections(fieldValue, name+".", labels)
+
+			if fieldValue.Kind() != reflect.Ptr || fieldValue.IsNil() || field.Tag.Get("label") != "allowEmpty" {
+				continue
+			}
+
+			// A section whose options are all embedded (i.e. providers.consul) is encoded as enabled by a "true" value.
+			if !hasLabel(labels, name) || labels[name] == "true" {
+				labels[name] = ""
+			}
+		}
+	}
+}
+
+// hasLabel reports whether there is a label for the section or one of its options.
+func hasLabel(labels map[string]string, section string) bool {
+	for key := range labels {
+		if key == section || strings.HasPrefix(key, section+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSection reports whether the type is a section of the configuration, and not an option.
+func isSection(rType reflect.Type) bool {
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	return rType != nil && rType.Kind() == reflect.Struct
+}
+
+// formatValue formats an encoded value as Traefik parses it from the CLI flags.
+// The durations are encoded as a number of nanoseconds, while Traefik reads a number as seconds.
+// The floats are encoded with a fixed precision (i.e. 0.100000), they are shortened.
+func formatValue(value string, rType reflect.Type) string {
+	if rType == floatSliceType {
+		return formatFloats(value)
+	}
+
+	if rType != durationType {
+		return value
+	}
+
+	nanoseconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return value
+	}
+
+	return time.Duration(nanoseconds).String()
+}
+
+// formatFloats formats a list of encoded floats in their shortest representation.
+func formatFloats(value string) string {
+	elements := strings.Split(value, ",")
+	for i, element := range elements {
+		f, err := strconv.ParseFloat(strings.TrimSpace(element), 64)
+		if err != nil {
+			return value
+		}
+
+		elements[i] = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+
+	return strings.Join(elements, ",")
+}
+
+// resolveKey lowercases the option names of a label key, as Traefik expects them, and returns the type of the option.
+// The map keys (i.e. entry point or certificates resolver names) are kept as is.
+func resolveKey(key string) (string, reflect.Type) {
+	rType := reflect.TypeOf(static.Configuration{})
+
+	segments := strings.Split(key, ".")
+	for i, segment := range segments {
+		for rType != nil && (rType.Kind() == reflect.Ptr || rType.Kind() == reflect.Slice) {
+			rType = rType.Elem()
+		}
+
+		if rType != nil && rType.Kind() == reflect.Map {
+			rType = rType.Elem()
+			continue
+		}
+
+		segments[i] = strings.ToLower(segment)
+
+		if rType == nil || rType.Kind() != reflect.Struct {
+			rType = nil
+			continue
+		}
+
+		// The slice indexes (i.e. domains[0]) are not part of the field name.
+		name := strings.SplitN(segment, "[", 2)[0]
+		field, ok := rType.FieldByNameFunc(func(fieldName string) bool {
+			return strings.EqualFold(fieldName, name)
+		})
+		if !ok {
+			rType = nil
+			continue
+		}
+
+		rType = field.Type
+	}
+
+	return strings.Join(segments, "."), rType
+}
+
+func getLabels(conf *static.Configuration, prefix string, includeDefaults bool) ([]string, error) {
+	var labels []string
+
+	cleanedLabels, err := getCleanedLabels(conf, includeDefaults)
+	if err != nil {
+		return labels, err
+	}
+
+	for key, value := range cleanedLabels {
+		if value == "" {
+			labels = append(labels, prefix+key)
+			continue
+		}
+
+		labels = append(labels, fmt.Sprintf("%s%s=%s", prefix, key, value))
+	}
+	// To keep the result consistent.
+	sort.Strings(labels)
+
+	return labels, nil
+}
+
+// envNameReplacer converts a label key to an environment variable name,
+// with the list indexes as segments (i.e. domains[0].main to domains_0_main),
+// as the brackets are rejected by the shells, the systemd environment files and Kubernetes.
+var envNameReplacer = strings.NewReplacer(".", "_", "[", "_", "]", "")
+
+func getEnvVars(conf *static.Configuration, includeDefaults bool) ([]string, error) {
+	var envVars []string
+
+	cleanedLabels, err := getCleanedLabels(conf, includeDefaults)
+	if err != nil {
+		return envVars, err
+	}
+
+	for key, value := range cleanedLabels {
+		// An enabled provider without options.
+		if value == "" {
+			value = "true"
+		}
+
+		name := env.DefaultNamePrefix + strings.ToUpper(envNameReplacer.Replace(key))
+		envVars = append(envVars, fmt.Sprintf("%s=%s", name, value))
+	}
+	// To keep the result consistent.
+	sort.Strings(envVars)
+
+	return envVars, nil
+}
+
+// getPorts returns the ports of the entry points to publish.
+// The traefik entry point serves the insecure API and the metrics, it is kept internal unless publishInternal is set.
+func getPorts(conf *static.Configuration, publishInternal bool) ([]entryPoint, error) {
+	var ports []entryPoint
+
+	for name, entrypoint := range conf.EntryPoints {
+		if name == internalEntryPointName && !publishInternal {
+			continue
+		}
+
+		_, port, err := net.SplitHostPort(entrypoint.Address)
+		if err != nil {
+			return ports, fmt.Errorf("cannot process ports :%w", err)
+		}
+
+		ports = append(ports, entryPoint{
+			Name:  name,
+			Value: port,
+		})
+	}
+
+	if port, ok := getInternalEntryPoint(conf); ok && publishInternal {
+		ports = append(ports, port)
+	}
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].Name < ports[j].Name
+	})
+
+	return ports, nil
+}
+
+// getInternalEntryPoint returns the traefik entry point when Traefik creates it,
+// for the insecure API or the Prometheus metrics, and it is not defined by the configuration.
+func getInternalEntryPoint(conf *static.Configuration) (entryPoint, bool) {
+	if _, ok := conf.EntryPoints[internalEntryPointName]; ok {
+		return entryPoint{}, false
+	}
+
+	prometheus := conf.Metrics != nil && conf.Metrics.Prometheus != nil && !conf.Metrics.Prometheus.ManualRouting &&
+		prometheusEntryPoint(conf.Metrics.Prometheus) == internalEntryPointName
+	insecureAPI := conf.API != nil && conf.API.Insecure
+
+	if !prometheus && !insecureAPI {
+		return entryPoint{}, false
+	}
+
+	return entryPoint{Name: internalEntryPointName, Value: internalEntryPointPort}, true
+}
+
+// Toml exports static configuration to a toml format.
+func Toml(config *static.Configuration, opts Options, output io.Writer) error {
+	options, err := getFileOptions(config, opts.IncludeDefaults, "toml")
+	if err != nil {
+		return err
+	}
+
+	if err := toml.NewEncoder(output).Encode(options); err != nil {
+		// failed to encode
+		return fmt.Errorf("cannot encode static configuration in TOML: %w", err)
+	}
+
+	return nil
+}
+
+// Yaml exports static configuration to a yaml format.
+func Yaml(config *static.Configuration, opts Options, output io.Writer) error {
+	options, err := getFileOptions(config, opts.IncludeDefaults, "yaml")
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.NewEncoder(output).Encode(options); err != nil {
+		// failed to encode
+		return fmt.Errorf("cannot encode static configuration in YAML: %w", err)
+	}
+
+	return nil
+}
+
+// JSON exports static configuration to a json format.
+func JSON(config *static.Configuration, opts Options, output io.Writer) error {
+	options, err := getFileOptions(config, opts.IncludeDefaults, "json")
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(options); err != nil {
+		// failed to encode
+		return fmt.Errorf("cannot encode static configuration in JSON: %w", err)
+	}
+
+	return nil
+}
+
+// CLIStyle is the layout of the CLI format.
+type CLIStyle string
+
+// CLI styles.
+const (
+	// CLIStyleInline writes the flags on a single line, quoted for a POSIX shell.
+	CLIStyleInline CLIStyle = "inline"
+	// CLIStyleMultiline writes one flag per line with `\` line continuations, quoted for a POSIX shell.
+	CLIStyleMultiline CLIStyle = "multiline"
+	// CLIStyleJSON writes the flags as a JSON array, as expected by the Dockerfile CMD and ENTRYPOINT instructions.
+	CLIStyleJSON CLIStyle = "json"
+)
+
+// shellSafeRegexp matches the strings which don't need to be quoted in a POSIX shell.
+var shellSafeRegexp = regexp.MustCompile(`^[A-Za-z0-9_@%+=:./-]+$`)
+
+// CLI exports static configuration to a CLI format, with the style of the options (inline by default).
+func CLI(config *static.Configuration, opts Options, output io.Writer) error {
+	labels, err := getLabels(config, "--", opts.IncludeDefaults)
+	if err != nil {
+		return err
+	}
+	sort.Strings(labels)
+
+	var str string
+	switch style := opts.Style; style {
+	case CLIStyleInline, "":
+		str = strings.Join(shellQuoteFlags(labels), " ")
+	case CLIStyleMultiline:
+		str = strings.Join(shellQuoteFlags(labels), " \\\n  ")
+	case CLIStyleJSON:
+		str, err = jsonArgs(labels)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported CLI style: %s", style)
+	}
+
+	_, err = output.Write([]byte(str + "\n"))
+	if err != nil {
+		return fmt.Errorf("cannot write to the standard output: %w", err)
+	}
+
+	return nil
+}
+
+// shellQuoteFlags quotes the values of the flags for a POSIX shell.
+func shellQuoteFlags(flags []string) []string {
+	quoted := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		n := strings.SplitN(flag, "=", 2)
+		if len(n) == 1 {
+			quoted = append(quoted, shellQuote(flag))
+			continue
+		}
+
+		quoted = append(quoted, shellQuote(n[0])+"="+shellQuote(n[1]))
+	}
+
+	return quoted
+}
+
+// shellQuote quotes a string for a POSIX shell, if needed.
+// Within single quotes nothing is interpreted, so a single quote is written by closing the quotes,
+// escaping it and reopening the quotes.
+func shellQuote(value string) string {
+	if shellSafeRegexp.MatchString(value) {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// jsonArgs returns the flags as a JSON array.
+func jsonArgs(flags []string) (string, error) {
+	args := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		buf := new(bytes.Buffer)
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+
+		if err := encoder.Encode(flag); err != nil {
+			return "", fmt.Errorf("cannot encode flag in JSON: %w", err)
+		}
+
+		args = append(args, strings.TrimSuffix(buf.String(), "\n"))
+	}
+
+	return "[" + strings.Join(args, ", ") + "]", nil
+}
+
+// Env exports static configuration to an environment variables format.
+func Env(config *static.Configuration, opts Options, output io.Writer) error {
+	envVars, err := getEnvVars(config, opts.IncludeDefaults)
+	if err != nil {
+		return err
+	}
+
+	for _, envVar := range envVars {
+		_, err = output.Write([]byte(envVar + "\n"))
+		if err != nil {
+			return fmt.Errorf("cannot write to the standard output: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Kubernetes export static configuration to a kubernetes crd format.
+// The bundled template is used unless the Template option is set.
+func Kubernetes(config *static.Configuration, opts Options, output io.Writer) error {
+	tmpl, err := loadTemplate(opts.Template, KubernetesTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to create the template: %w", err)
+	}
+
+	return executeTemplate(config, tmpl, true, true, opts, output)
+}
+
+// Docker export static configuration to docker-compose format.
+// The bundled template is used unless the Template option is set.
+func Docker(config *static.Configuration, opts Options, output io.Writer) error {
+	tmpl, err := loadTemplate(opts.Template, DockerTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to create the template: %w", err)
+	}
+
+	return executeTemplate(config, tmpl, true, true, opts, output)
+}
+
+// Template export static configuration with a template of the registry,
+// and the options needed by the template (i.e. the dashboard router).
+// The labels and the ports are only computed when the template needs them.
+func Template(config *static.Configuration, info TemplateInfo, opts Options, output io.Writer) error {
+	var (
+		tmpl *template.Template
+		err  error
+	)
+
+	if info.Bundled {
+		tmpl, err = loadTemplate("", info.Path)
+	} else {
+		tmpl, err = loadTemplate(info.Path, "")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create the template %s: %w", info.Name, err)
+	}
+
+	return executeTemplate(config, tmpl, info.NeedsLabels, info.NeedsPorts, opts, output)
+}
+
+func executeTemplate(config *static.Configuration, tmpl *template.Template, needsLabels, needsPorts bool, opts Options, output io.Writer) error {
+	config = withPersistentStorages(config)
+
+	dashboard, err := getDashboardRouter(config, opts.Dashboard)
+	if err != nil {
+		return err
+	}
+
+	prometheus, err := getPrometheusMetrics(config, opts.ServiceMonitor)
+	if err != nil {
+		return err
+	}
+
+	var companion *tracingCompanion
+	if opts.TracingCompanion {
+		companion, err = getTracingCompanion(config)
+		if err != nil {
+			return err
+		}
+	}
+
+	data := traefikConf{
+		Configuration:    config,
+		Volumes:          getVolumes(config),
+		SecretEnvVars:    getSecretEnvVars(config),
+		Dashboard:        dashboard,
+		Prometheus:       prometheus,
+		TracingCompanion: companion,
+	}
+
+	if needsLabels {
+		labels, err := getLabels(config, "", opts.IncludeDefaults)
+		if err != nil {
+			return err
+		}
+		data.Labels = labels
+	}
+
+	if needsPorts {
+		ports, err := getPorts(config, opts.PublishInternal)
+		if err != nil {
+			return fmt.Errorf("failed to get ports from static configuration: %w", err)
+		}
+		data.Ports = ports
+	}
+
+	err = tmpl.Execute(output, data)
+	if err != nil {
+		return fmt.Errorf("failed to execute the template: %w", err)
+	}
+
+	return nil
+}
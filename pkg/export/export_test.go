@@ -0,0 +1,1178 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jbdoumenjou/baeker/pkg/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/acme"
+	"github.com/traefik/traefik/v2/pkg/provider/consulcatalog"
+	"github.com/traefik/traefik/v2/pkg/provider/docker"
+	"github.com/traefik/traefik/v2/pkg/provider/ecs"
+	"github.com/traefik/traefik/v2/pkg/provider/http"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/crd"
+	"github.com/traefik/traefik/v2/pkg/provider/kubernetes/ingress"
+	"github.com/traefik/traefik/v2/pkg/provider/kv"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/consul"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/etcd"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/redis"
+	"github.com/traefik/traefik/v2/pkg/provider/kv/zk"
+	"github.com/traefik/traefik/v2/pkg/provider/marathon"
+	"github.com/traefik/traefik/v2/pkg/provider/rancher"
+	"github.com/traefik/traefik/v2/pkg/provider/rest"
+	"github.com/traefik/traefik/v2/pkg/tracing/jaeger"
+	"github.com/traefik/traefik/v2/pkg/tracing/zipkin"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestTOMLExport(t *testing.T) {
+	t.Parallel()
+	configuration := &static.Configuration{
+		Log: &types.TraefikLog{
+			Level: "debug",
+		},
+	}
+	exportedConf := new(bytes.Buffer)
+	err := Toml(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/static.toml"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestYAMLExport(t *testing.T) {
+	t.Parallel()
+	configuration := &static.Configuration{
+		Log: &types.TraefikLog{
+			Level: "debug",
+		},
+	}
+	exportedConf := new(bytes.Buffer)
+	err := Yaml(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/static.yml"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestJSONExport(t *testing.T) {
+	t.Parallel()
+	configuration := &static.Configuration{
+		Log: &types.TraefikLog{
+			Level: "debug",
+		},
+	}
+	exportedConf := new(bytes.Buffer)
+	err := JSON(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/static.json"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	t.Parallel()
+	formats := []struct {
+		desc       string
+		export     ExporterFunc
+		importConf func(io.Reader) (*static.Configuration, error)
+	}{
+		{desc: "toml", export: Toml, importConf: importer.Toml},
+		{desc: "yaml", export: Yaml, importConf: importer.Yaml},
+		{desc: "json", export: JSON, importConf: importer.JSON},
+	}
+
+	testcases := []struct {
+		filePath   string
+		importConf func(io.Reader) (*static.Configuration, error)
+	}{
+		{filePath: "./fixtures/empty.yml", importConf: importer.Yaml},
+		{filePath: "./fixtures/static.yml", importConf: importer.Yaml},
+		{filePath: "./fixtures/round-trip.yml", importConf: importer.Yaml},
+		{filePath: "./fixtures/empty.toml", importConf: importer.Toml},
+		{filePath: "./fixtures/static.toml", importConf: importer.Toml},
+		{filePath: "./fixtures/command.cli", importConf: importer.CLI},
+		{filePath: "./fixtures/static.env", importConf: importer.EnvFile},
+		{filePath: "./fixtures/static.json", importConf: importer.JSON},
+	}
+
+	for _, format := range formats {
+		for _, test := range testcases {
+			format, test := format, test
+			t.Run(format.desc+" "+test.filePath, func(t *testing.T) {
+				t.Parallel()
+
+				confReader, err := os.Open(filepath.FromSlash(test.filePath))
+				require.NoError(t, err)
+				defer func() { _ = confReader.Close() }()
+
+				configuration, err := test.importConf(confReader)
+				require.NoError(t, err)
+
+				exportedConf := new(bytes.Buffer)
+				err = format.export(configuration, Options{}, exportedConf)
+				require.NoError(t, err)
+
+				importedConf, err := format.importConf(exportedConf)
+				require.NoError(t, err)
+
+				assert.Equal(t, configuration, importedConf)
+			})
+		}
+	}
+}
+
+func TestCLIExport(t *testing.T) {
+	t.Parallel()
+	configuration := &static.Configuration{
+		Log: &types.TraefikLog{
+			Level: "DEBUG",
+		},
+	}
+	exportedConf := new(bytes.Buffer)
+	err := CLI(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/static.cli"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestEnvExport(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: ":8443"},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{},
+		},
+		Log: &types.TraefikLog{
+			Level: "DEBUG",
+		},
+	})
+	exportedConf := new(bytes.Buffer)
+	err := Env(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/docker.env"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestEnvExportLists(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"websecure": {
+				Address: ":8443",
+				HTTP: static.HTTPConfig{TLS: &static.TLSConfig{
+					Domains: []types.Domain{{Main: "example.com", SANs: []string{"www.example.com"}}},
+				}},
+			},
+		},
+	})
+
+	exportedConf := new(bytes.Buffer)
+	err := Env(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expected := `TRAEFIK_ENTRYPOINTS_WEBSECURE_ADDRESS=:8443
+TRAEFIK_ENTRYPOINTS_WEBSECURE_HTTP_TLS_DOMAINS_0_MAIN=example.com
+TRAEFIK_ENTRYPOINTS_WEBSECURE_HTTP_TLS_DOMAINS_0_SANS=www.example.com
+`
+	assert.Equal(t, expected, exportedConf.String())
+
+	importedConf, err := importer.EnvFile(exportedConf)
+	require.NoError(t, err)
+	assert.Equal(t, configuration.EntryPoints["websecure"].HTTP.TLS.Domains, importedConf.EntryPoints["websecure"].HTTP.TLS.Domains)
+}
+
+func TestKubernetesExport(t *testing.T) {
+	t.Parallel()
+	configuration := &static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: ":8443"},
+		},
+		Providers: &static.Providers{
+			KubernetesCRD: &crd.Provider{},
+		},
+	}
+	exportedConf := new(bytes.Buffer)
+	err := Kubernetes(configuration, Options{Template: "traefik-lb-svc-tpl.yml"}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/traefik-lb-svc.yml"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestDockerExport(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: ":8443"},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{},
+		},
+	})
+	exportedConf := new(bytes.Buffer)
+	err := Kubernetes(configuration, Options{Template: "docker-compose-tpl.yml"}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/docker-compose.yml"))
+
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+// mixedCaseConfiguration returns a configuration with case-sensitive names and values.
+func mixedCaseConfiguration() *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"webSecure": {Address: ":8443"},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{
+				Endpoint: "unix:///var/run/Docker.sock",
+				Network:  "Traefik_Net",
+			},
+		},
+		Log: &types.TraefikLog{
+			FilePath: "/var/log/Traefik.log",
+		},
+		CertificatesResolvers: map[string]static.CertificateResolver{
+			"myResolver": {ACME: &acme.Configuration{Email: "Admin@Example.com", Storage: "/Letsencrypt/acme.json"}},
+		},
+	})
+}
+
+func TestBundledTemplatesExport(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: ":8443"},
+		},
+		Providers: &static.Providers{
+			KubernetesCRD: &crd.Provider{},
+		},
+	})
+	exportedConf := new(bytes.Buffer)
+	err := Kubernetes(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/traefik-lb-svc.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+
+	configuration.Providers = withDefaults(&static.Configuration{
+		Providers: &static.Providers{Docker: &docker.Provider{}},
+	}).Providers
+	exportedConf = new(bytes.Buffer)
+	err = Docker(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err = ioutil.ReadFile(filepath.FromSlash("./fixtures/docker-compose.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestMixedCaseExport(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		export      ExporterFunc
+		opts        Options
+	}{
+		{
+			description: "cli",
+			filePath:    "./fixtures/mixed-case.cli",
+			export:      CLI,
+		},
+		{
+			description: "env",
+			filePath:    "./fixtures/mixed-case.env",
+			export:      Env,
+		},
+		{
+			description: "docker",
+			filePath:    "./fixtures/mixed-case-docker-compose.yml",
+			export:      Docker,
+			opts:        Options{Template: "docker-compose-tpl.yml"},
+		},
+		{
+			description: "kubernetes",
+			filePath:    "./fixtures/mixed-case-traefik-lb-svc.yml",
+			export:      Kubernetes,
+			opts:        Options{Template: "traefik-lb-svc-tpl.yml"},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			err := test.export(mixedCaseConfiguration(), test.opts, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+// quotingConfiguration returns a configuration with values which must be quoted in a shell.
+func quotingConfiguration() *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {
+				Address: ":8000",
+				HTTP: static.HTTPConfig{
+					Middlewares: []string{"compress@file", "auth@file"},
+				},
+			},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{
+				DefaultRule: "Host(`{{ normalize .Name }}.example.com`)",
+			},
+		},
+		Log: &types.TraefikLog{
+			FilePath: "/var/log/it's $HOME/traefik.log",
+		},
+	})
+}
+
+func TestCLIExportWithStyle(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		style    CLIStyle
+		filePath string
+	}{
+		{style: CLIStyleInline, filePath: "./fixtures/quoted.cli"},
+		{style: CLIStyleMultiline, filePath: "./fixtures/quoted-multiline.cli"},
+		{style: CLIStyleJSON, filePath: "./fixtures/quoted.json"},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(string(test.style), func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			err := CLI(quotingConfiguration(), Options{Style: test.style}, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+func TestCLIExportRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, style := range []CLIStyle{CLIStyleInline, CLIStyleMultiline} {
+		exportedConf := new(bytes.Buffer)
+		err := CLI(quotingConfiguration(), Options{Style: style}, exportedConf)
+		require.NoError(t, err)
+
+		importedConf, err := importer.CLI(exportedConf)
+		require.NoError(t, err)
+
+		expected := quotingConfiguration()
+		assert.Equal(t, expected.Log.FilePath, importedConf.Log.FilePath)
+		assert.Equal(t, expected.Providers.Docker.DefaultRule, importedConf.Providers.Docker.DefaultRule)
+		assert.Equal(t, expected.EntryPoints["web"].HTTP.Middlewares, importedConf.EntryPoints["web"].HTTP.Middlewares)
+	}
+}
+
+func entryPointsConfiguration() *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {
+				Address: ":8000",
+				HTTP: static.HTTPConfig{
+					Redirections: &static.Redirections{
+						EntryPoint: &static.RedirectEntryPoint{To: "websecure", Scheme: "https", Permanent: true},
+					},
+				},
+				ForwardedHeaders: &static.ForwardedHeaders{TrustedIPs: []string{"10.0.0.0/8"}},
+			},
+			"websecure": {
+				Address: ":8443",
+				HTTP: static.HTTPConfig{
+					TLS: &static.TLSConfig{CertResolver: "le"},
+				},
+				ProxyProtocol: &static.ProxyProtocol{Insecure: true},
+				Transport: &static.EntryPointsTransport{
+					RespondingTimeouts: &static.RespondingTimeouts{ReadTimeout: ptypes.Duration(10 * time.Second)},
+					LifeCycle:          &static.LifeCycle{GraceTimeOut: ptypes.Duration(90 * time.Second)},
+				},
+			},
+		},
+	})
+}
+
+func TestEntryPointsCLIExport(t *testing.T) {
+	t.Parallel()
+	exportedConf := new(bytes.Buffer)
+	err := CLI(entryPointsConfiguration(), Options{Style: CLIStyleMultiline}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/entrypoints.cli"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+
+	// The durations are read back as durations, not as seconds.
+	importedConf, err := importer.CLI(exportedConf)
+	require.NoError(t, err)
+
+	transport := importedConf.EntryPoints["websecure"].Transport
+	assert.Equal(t, ptypes.Duration(10*time.Second), transport.RespondingTimeouts.ReadTimeout)
+	assert.Equal(t, ptypes.Duration(90*time.Second), transport.LifeCycle.GraceTimeOut)
+}
+
+func acmeConfiguration() *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: ":8443", HTTP: static.HTTPConfig{TLS: &static.TLSConfig{}}},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{},
+		},
+		CertificatesResolvers: map[string]static.CertificateResolver{
+			"le": {ACME: &acme.Configuration{
+				Email:        "admin@example.com",
+				Storage:      "/letsencrypt/acme.json",
+				TLSChallenge: &acme.TLSChallenge{},
+			}},
+			"dns": {ACME: &acme.Configuration{
+				Email:        "admin@example.com",
+				CAServer:     "https://acme-staging-v02.api.letsencrypt.org/directory",
+				Storage:      "/letsencrypt/dns.json",
+				DNSChallenge: &acme.DNSChallenge{Provider: "route53"},
+			}},
+		},
+	})
+}
+
+func TestACMEExport(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc     string
+		export   ExporterFunc
+		opts     Options
+		expected string
+	}{
+		{
+			desc:     "cli",
+			export:   CLI,
+			opts:     Options{Style: CLIStyleMultiline},
+			expected: "./fixtures/acme.cli",
+		},
+		{
+			desc:     "docker",
+			export:   Docker,
+			expected: "./fixtures/acme-docker-compose.yml",
+		},
+		{
+			desc:     "kubernetes",
+			export:   Kubernetes,
+			expected: "./fixtures/acme-traefik-lb-svc.yml",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			err := test.export(acmeConfiguration(), test.opts, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.expected))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+func TestACMEDefaultStorageExport(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc     string
+		export   ExporterFunc
+		expected []string
+	}{
+		{
+			desc:   "docker",
+			export: Docker,
+			expected: []string{
+				"      - letsencrypt:/letsencrypt\n",
+				"      - --certificatesresolvers.le.acme.storage=/letsencrypt/acme.json\n",
+				"volumes:\n  letsencrypt:\n",
+			},
+		},
+		{
+			desc:   "kubernetes",
+			export: Kubernetes,
+			expected: []string{
+				"            - name: letsencrypt\n              mountPath: /letsencrypt\n",
+				"            - --certificatesresolvers.le.acme.storage=/letsencrypt/acme.json\n",
+				"kind: PersistentVolumeClaim\nmetadata:\n  name: traefik-letsencrypt\n",
+			},
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			// The builder sets the storage to the default acme.json, at the root of the container.
+			configuration := withDefaults(&static.Configuration{
+				EntryPoints: map[string]*static.EntryPoint{"websecure": {Address: ":8443"}},
+				CertificatesResolvers: map[string]static.CertificateResolver{
+					"le": {ACME: &acme.Configuration{Email: "admin@example.com", Storage: "acme.json", TLSChallenge: &acme.TLSChallenge{}}},
+				},
+			})
+
+			exportedConf := new(bytes.Buffer)
+			err := test.export(configuration, Options{}, exportedConf)
+			require.NoError(t, err)
+
+			for _, expected := range test.expected {
+				assert.Contains(t, exportedConf.String(), expected)
+			}
+
+			// The exported configuration is not modified.
+			assert.Equal(t, "acme.json", configuration.CertificatesResolvers["le"].ACME.Storage)
+		})
+	}
+}
+
+func dashboardConfiguration(insecure bool) *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: ":8443", HTTP: static.HTTPConfig{TLS: &static.TLSConfig{CertResolver: "le"}}},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{},
+		},
+		API: &static.API{Dashboard: true, Insecure: insecure},
+	})
+}
+
+func TestDashboardExport(t *testing.T) {
+	t.Parallel()
+	dashboard := &Dashboard{
+		Host:  "traefik.example.com",
+		Users: []string{"admin:$apr1$H6uskkkW$IgXLP6ewTrSuBkTrqE8wj/", "ops:$apr1$d9hr9HBB$4HxwgUir3HP4EsggP/QNo0"},
+	}
+
+	testcases := []struct {
+		desc            string
+		format          string
+		insecure        bool
+		publishInternal bool
+		expected        string
+	}{
+		{
+			desc:     "docker",
+			format:   "docker",
+			expected: "./fixtures/dashboard-docker-compose.yml",
+		},
+		{
+			desc:     "kubernetes",
+			format:   "kubernetes",
+			expected: "./fixtures/dashboard-traefik-lb-svc.yml",
+		},
+		{
+			desc:            "insecure docker",
+			format:          "docker",
+			insecure:        true,
+			publishInternal: true,
+			expected:        "./fixtures/insecure-dashboard-docker-compose.yml",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			opts := Options{Dashboard: dashboard, PublishInternal: test.publishInternal}
+			err := Formats.Export(test.format, dashboardConfiguration(test.insecure), opts, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.expected))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+func TestDashboardEntryPoint(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc        string
+		entryPoints []string
+		entryPoint  string
+		expected    string
+		expectedErr string
+	}{
+		{
+			desc:        "websecure",
+			entryPoints: []string{"metrics", "web", "websecure"},
+			expected:    "websecure",
+		},
+		{
+			desc:        "web without websecure",
+			entryPoints: []string{"metrics", "web"},
+			expected:    "web",
+		},
+		{
+			desc:        "explicit entry point",
+			entryPoints: []string{"admin", "web", "websecure"},
+			entryPoint:  "admin",
+			expected:    "admin",
+		},
+		{
+			desc:        "unknown explicit entry point",
+			entryPoints: []string{"web"},
+			entryPoint:  "admin",
+			expectedErr: `the entry point "admin" of the dashboard router is not defined`,
+		},
+		{
+			desc:        "neither websecure nor web",
+			entryPoints: []string{"http", "metrics"},
+			expectedErr: "there is no websecure or web entry point, the entry point of the dashboard router must be set",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			configuration := &static.Configuration{
+				EntryPoints: map[string]*static.EntryPoint{},
+				API:         &static.API{Dashboard: true},
+			}
+			for _, name := range test.entryPoints {
+				configuration.EntryPoints[name] = &static.EntryPoint{Address: ":8000"}
+			}
+
+			dashboard := &Dashboard{Host: "traefik.example.com", Users: []string{"admin:secret"}, EntryPoint: test.entryPoint}
+
+			router, err := getDashboardRouter(configuration, dashboard)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, router.EntryPoint)
+		})
+	}
+}
+
+func TestDashboardExportWithoutUsers(t *testing.T) {
+	t.Parallel()
+	err := Formats.Export("docker", dashboardConfiguration(false), Options{Dashboard: &Dashboard{Host: "traefik.example.com"}}, new(bytes.Buffer))
+	assert.EqualError(t, err, "the dashboard router needs at least one basic auth user")
+}
+
+func metricsConfiguration(entryPoint string) *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":     {Address: ":8000"},
+			"metrics": {Address: ":8082"},
+		},
+		Providers: &static.Providers{
+			KubernetesCRD: &crd.Provider{},
+		},
+		Metrics: &types.Metrics{
+			Prometheus: &types.Prometheus{EntryPoint: entryPoint, Buckets: []float64{0.1, 0.3, 1.2, 5}},
+			Datadog:    &types.Datadog{Address: "datadog:8125", PushInterval: ptypes.Duration(15 * time.Second)},
+			StatsD:     &types.Statsd{},
+		},
+	})
+}
+
+func TestMetricsExport(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc           string
+		format         string
+		entryPoint     string
+		serviceMonitor bool
+		expected       string
+	}{
+		{
+			desc:       "cli",
+			format:     "cli",
+			entryPoint: "metrics",
+			expected:   "./fixtures/metrics.cli",
+		},
+		{
+			desc:           "kubernetes with a service monitor",
+			format:         "kubernetes",
+			entryPoint:     "metrics",
+			serviceMonitor: true,
+			expected:       "./fixtures/metrics-traefik-lb-svc.yml",
+		},
+		{
+			desc:     "kubernetes on the traefik entry point",
+			format:   "kubernetes",
+			expected: "./fixtures/metrics-internal-traefik-lb-svc.yml",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			opts := Options{Style: CLIStyleMultiline, ServiceMonitor: test.serviceMonitor}
+			err := Formats.Export(test.format, metricsConfiguration(test.entryPoint), opts, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.expected))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+func TestInternalEntryPointPorts(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":     {Address: ":8000"},
+			"traefik": {Address: ":9000"},
+		},
+		API: &static.API{Insecure: true},
+	})
+
+	ports, err := getPorts(configuration, false)
+	require.NoError(t, err)
+	assert.Equal(t, []entryPoint{{Name: "web", Value: "8000"}}, ports)
+
+	ports, err = getPorts(configuration, true)
+	require.NoError(t, err)
+	assert.Equal(t, []entryPoint{{Name: "traefik", Value: "9000"}, {Name: "web", Value: "8000"}}, ports)
+
+	delete(configuration.EntryPoints, "traefik")
+
+	ports, err = getPorts(configuration, true)
+	require.NoError(t, err)
+	assert.Equal(t, []entryPoint{{Name: "traefik", Value: "8080"}, {Name: "web", Value: "8000"}}, ports)
+}
+
+func TestMetricsExportUnknownEntryPoint(t *testing.T) {
+	t.Parallel()
+	err := Formats.Export("kubernetes", metricsConfiguration("unknown"), Options{}, new(bytes.Buffer))
+	assert.EqualError(t, err, "the Prometheus entry point unknown does not exist")
+}
+
+func tracingConfiguration(tracing *static.Tracing) *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {Address: ":8000"},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{},
+		},
+		Tracing: tracing,
+	})
+}
+
+func TestTracingExport(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc     string
+		format   string
+		tracing  *static.Tracing
+		expected string
+	}{
+		{
+			desc:   "cli",
+			format: "cli",
+			tracing: &static.Tracing{
+				ServiceName:   "proxy",
+				SpanNameLimit: 64,
+				Jaeger:        &jaeger.Config{Collector: &jaeger.Collector{Endpoint: "http://collector:14268/api/traces"}},
+			},
+			expected: "./fixtures/tracing.cli",
+		},
+		{
+			desc:     "docker with a jaeger companion",
+			format:   "docker",
+			tracing:  &static.Tracing{Jaeger: &jaeger.Config{}},
+			expected: "./fixtures/tracing-jaeger-docker-compose.yml",
+		},
+		{
+			desc:     "kubernetes with a zipkin sidecar",
+			format:   "kubernetes",
+			tracing:  &static.Tracing{Zipkin: &zipkin.Config{HTTPEndpoint: "http://localhost:9412/api/v2/spans"}},
+			expected: "./fixtures/tracing-zipkin-traefik-lb-svc.yml",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			opts := Options{Style: CLIStyleMultiline, TracingCompanion: true}
+			err := Formats.Export(test.format, tracingConfiguration(test.tracing), opts, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.expected))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+func TestTracingCompanionRemoteEndpoint(t *testing.T) {
+	t.Parallel()
+	conf := tracingConfiguration(&static.Tracing{Jaeger: &jaeger.Config{LocalAgentHostPort: "jaeger:6831"}})
+
+	err := Formats.Export("docker", conf, Options{TracingCompanion: true}, new(bytes.Buffer))
+	assert.EqualError(t, err, "invalid Jaeger agent address for the tracing companion: jaeger:6831 is not a local address")
+
+	err = Formats.Export("docker", conf, Options{}, new(bytes.Buffer))
+	assert.NoError(t, err)
+}
+
+func logConfiguration() *static.Configuration {
+	return withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {Address: ":8000"},
+		},
+		Providers: &static.Providers{
+			KubernetesCRD: &crd.Provider{},
+		},
+		Log: &types.TraefikLog{
+			Level:    "DEBUG",
+			FilePath: "/var/log/traefik/traefik.log",
+		},
+		AccessLog: &types.AccessLog{
+			FilePath:      "/var/log/traefik/access.log",
+			Format:        "json",
+			BufferingSize: 100,
+			Filters: &types.AccessLogFilters{
+				StatusCodes:   []string{"400-499", "500-599"},
+				RetryAttempts: true,
+				MinDuration:   ptypes.Duration(10 * time.Millisecond),
+			},
+			Fields: &types.AccessLogFields{
+				Names: map[string]string{"ClientUsername": types.AccessLogDrop},
+				Headers: &types.FieldHeaders{
+					DefaultMode: types.AccessLogDrop,
+					Names:       map[string]string{"Authorization": types.AccessLogRedact},
+				},
+			},
+		},
+	})
+}
+
+func TestLogExport(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		desc     string
+		format   string
+		expected string
+	}{
+		{
+			desc:     "cli",
+			format:   "cli",
+			expected: "./fixtures/log.cli",
+		},
+		{
+			desc:     "docker",
+			format:   "docker",
+			expected: "./fixtures/log-docker-compose.yml",
+		},
+		{
+			desc:     "kubernetes",
+			format:   "kubernetes",
+			expected: "./fixtures/log-traefik-lb-svc.yml",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			exportedConf := new(bytes.Buffer)
+			err := Formats.Export(test.format, logConfiguration(), Options{Style: CLIStyleMultiline}, exportedConf)
+			require.NoError(t, err)
+
+			expectedConf, err := ioutil.ReadFile(filepath.FromSlash(test.expected))
+			require.NoError(t, err)
+
+			assert.Equal(t, string(expectedConf), exportedConf.String())
+		})
+	}
+}
+
+func TestEmptySectionsExport(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		API:       &static.API{},
+		AccessLog: &types.AccessLog{},
+		Metrics:   &types.Metrics{StatsD: &types.Statsd{}},
+	})
+
+	exportedConf := new(bytes.Buffer)
+	err := CLI(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	assert.Equal(t, "--accesslog --api --metrics.statsd\n", exportedConf.String())
+}
+
+func TestProvidersExport(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		Providers: &static.Providers{
+			Docker:            &docker.Provider{},
+			KubernetesIngress: &ingress.Provider{},
+			KubernetesCRD:     &crd.Provider{Namespaces: []string{"default"}},
+			Marathon:          &marathon.Provider{},
+			Rancher:           &rancher.Provider{},
+			Rest:              &rest.Provider{},
+			ConsulCatalog:     &consulcatalog.Provider{Endpoint: &consulcatalog.EndpointConfig{Address: "consul:8500"}},
+			Ecs:               &ecs.Provider{Region: "eu-west-1"},
+			Consul:            &consul.Provider{},
+			Etcd:              &etcd.Provider{Provider: kv.Provider{RootKey: "edge", Endpoints: []string{"etcd:2379"}}},
+			ZooKeeper:         &zk.Provider{},
+			Redis:             &redis.Provider{},
+			HTTP:              &http.Provider{Endpoint: "http://config/dynamic", PollInterval: ptypes.Duration(10 * time.Second)},
+		},
+	})
+
+	exportedConf := new(bytes.Buffer)
+	err := CLI(configuration, Options{Style: CLIStyleMultiline}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/providers.cli"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestDefaultsExport(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{"web": {Address: ":8000"}},
+		Providers:   &static.Providers{Docker: &docker.Provider{ExposedByDefault: true}},
+	})
+	configuration.Providers.Docker.Watch = false
+
+	testCases := []struct {
+		desc     string
+		format   string
+		opts     Options
+		expected string
+	}{
+		{
+			desc:     "cli",
+			format:   "cli",
+			expected: "--entrypoints.web.address=:8000 --providers.docker.watch=false\n",
+		},
+		{
+			desc:     "env",
+			format:   "env",
+			expected: "TRAEFIK_ENTRYPOINTS_WEB_ADDRESS=:8000\nTRAEFIK_PROVIDERS_DOCKER_WATCH=false\n",
+		},
+		{
+			desc:     "yaml",
+			format:   "yaml",
+			expected: "entryPoints:\n  web:\n    address: :8000\nproviders:\n  docker:\n    watch: false\n",
+		},
+		{
+			desc:     "toml",
+			format:   "toml",
+			expected: "[entryPoints]\n  [entryPoints.web]\n    address = \":8000\"\n\n[providers]\n  [providers.docker]\n    watch = false\n",
+		},
+		{
+			desc:   "cli with the defaults",
+			format: "cli",
+			opts:   Options{Style: CLIStyleMultiline, IncludeDefaults: true},
+			expected: `--entrypoints.web.address=:8000 \
+  --entrypoints.web.forwardedheaders.insecure=false \
+  --entrypoints.web.transport.lifecycle.gracetimeout=10s \
+  --entrypoints.web.transport.lifecycle.requestacceptgracetimeout=0s \
+  --entrypoints.web.transport.respondingtimeouts.idletimeout=3m0s \
+  --entrypoints.web.transport.respondingtimeouts.readtimeout=0s \
+  --entrypoints.web.transport.respondingtimeouts.writetimeout=0s \
+  --providers.docker.defaultrule='Host(` + "`{{ normalize .Name }}`" + `)' \
+  --providers.docker.endpoint=unix:///var/run/docker.sock \
+  --providers.docker.exposedbydefault=true \
+  --providers.docker.swarmmode=false \
+  --providers.docker.swarmmoderefreshseconds=15s \
+  --providers.docker.usebindportip=false \
+  --providers.docker.watch=false \
+  --providers.providersthrottleduration=2s
+`,
+		},
+		{
+			desc:   "yaml with the defaults",
+			format: "yaml",
+			opts:   Options{IncludeDefaults: true},
+			expected: `entryPoints:
+  web:
+    address: :8000
+    forwardedHeaders:
+      insecure: false
+    transport:
+      lifeCycle:
+        graceTimeOut: 10s
+        requestAcceptGraceTimeout: 0s
+      respondingTimeouts:
+        idleTimeout: 3m0s
+        readTimeout: 0s
+        writeTimeout: 0s
+providers:
+  docker:
+    defaultRule: Host(` + "`{{ normalize .Name }}`" + `)
+    endpoint: unix:///var/run/docker.sock
+    exposedByDefault: true
+    swarmMode: false
+    swarmModeRefreshSeconds: 15s
+    useBindPortIP: false
+    watch: false
+  providersThrottleDuration: 2s
+`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			format, ok := Formats.Get(test.format)
+			require.True(t, ok)
+
+			exportedConf := new(bytes.Buffer)
+			err := format.Exporter.Export(configuration, test.opts, exportedConf)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, exportedConf.String())
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+	testcases := map[string]string{
+		"--entrypoints.web.address=:8000": "--entrypoints.web.address=:8000",
+		"unix:///var/run/docker.sock":     "unix:///var/run/docker.sock",
+		"a b":                             "'a b'",
+		"$HOME":                           "'$HOME'",
+		"Host(`example.com`)":             "'Host(`example.com`)'",
+		"it's":                            `'it'\''s'`,
+		"a,b":                             "'a,b'",
+		"":                                "''",
+	}
+
+	for value, expected := range testcases {
+		assert.Equal(t, expected, shellQuote(value), value)
+	}
+}
+
+// withDefaults returns the configuration with the sections filled as the importers and the builder do:
+// each section starts from its default values, overridden by the options set in the given configuration.
+func withDefaults(conf *static.Configuration) *static.Configuration {
+	defaultConf := &static.Configuration{}
+	setDefaultSections(reflect.ValueOf(defaultConf).Elem(), reflect.ValueOf(conf).Elem())
+	overrideDefaults(reflect.ValueOf(defaultConf).Elem(), reflect.ValueOf(conf).Elem())
+
+	return defaultConf
+}
+
+func overrideDefaults(defaultValue, rValue reflect.Value) {
+	switch {
+	case rValue.Kind() == reflect.Ptr && rValue.Type().Elem().Kind() == reflect.Struct:
+		if !rValue.IsNil() {
+			overrideDefaults(defaultValue.Elem(), rValue.Elem())
+		}
+
+	case rValue.Kind() == reflect.Map && isSection(rValue.Type().Elem()):
+		for _, key := range rValue.MapKeys() {
+			elem := reflect.New(rValue.Type().Elem()).Elem()
+			elem.Set(defaultValue.MapIndex(key))
+			overrideDefaults(elem, rValue.MapIndex(key))
+			defaultValue.SetMapIndex(key, elem)
+		}
+
+	case rValue.Kind() == reflect.Struct:
+		for i := 0; i < rValue.NumField(); i++ {
+			if rValue.Type().Field(i).PkgPath == "" {
+				overrideDefaults(defaultValue.Field(i), rValue.Field(i))
+			}
+		}
+
+	default:
+		if !rValue.IsZero() {
+			defaultValue.Set(rValue)
+		}
+	}
+}
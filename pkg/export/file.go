@@ -0,0 +1,179 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// getFileOptions returns the options of the configuration which are not default values, unless they are included,
+// as a tree of sections named as in the file format of the struct tag (i.e. yaml).
+// The tree is built from the cleaned labels, as the CLI flags and the environment variables are,
+// so the options set to a zero value which is not their default (i.e. exposedByDefault: false) are kept.
+func getFileOptions(conf *static.Configuration, includeDefaults bool, tag string) (map[string]interface{}, error) {
+	labels, err := getCleanedLabels(conf, includeDefaults)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(map[string]interface{})
+	for key, value := range labels {
+		err = addFileOption(options, strings.Split(key, "."), reflect.TypeOf(static.Configuration{}), value, tag)
+		if err != nil {
+			return nil, fmt.Errorf("cannot export the option %s: %w", key, err)
+		}
+	}
+
+	return options, nil
+}
+
+// addFileOption adds to the section the option at the path of the segments, with a value of the option type.
+func addFileOption(section map[string]interface{}, segments []string, rType reflect.Type, value, tag string) error {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	var (
+		name  string
+		index = -1
+	)
+
+	switch rType.Kind() {
+	case reflect.Map:
+		// The map keys (i.e. the entry point names) are kept as is.
+		name = segments[0]
+		rType = rType.Elem()
+
+	case reflect.Struct:
+		fieldName, i, err := splitIndex(segments[0])
+		if err != nil {
+			return err
+		}
+
+		field, ok := rType.FieldByNameFunc(func(n string) bool {
+			return strings.EqualFold(n, fieldName)
+		})
+		if !ok {
+			return fmt.Errorf("unknown option %s", fieldName)
+		}
+
+		name = fileOptionName(field, tag)
+		rType = field.Type
+		index = i
+
+	default:
+		return fmt.Errorf("unexpected option %s", segments[0])
+	}
+
+	// An element of a list of sections (i.e. tls.domains[0]).
+	if index >= 0 {
+		list, _ := section[name].([]interface{})
+		for len(list) <= index {
+			list = append(list, make(map[string]interface{}))
+		}
+		section[name] = list
+
+		if len(segments) == 1 {
+			return nil
+		}
+
+		return addFileOption(list[index].(map[string]interface{}), segments[1:], rType.Elem(), value, tag)
+	}
+
+	if len(segments) > 1 {
+		subSection, ok := section[name].(map[string]interface{})
+		if !ok {
+			subSection = make(map[string]interface{})
+			section[name] = subSection
+		}
+
+		return addFileOption(subSection, segments[1:], rType, value, tag)
+	}
+
+	// A section enabled by its presence (i.e. providers.docker).
+	if isSection(rType) && (value == "" || value == "true") {
+		if _, ok := section[name]; !ok {
+			section[name] = make(map[string]interface{})
+		}
+
+		return nil
+	}
+
+	fileValue, err := parseFileValue(value, rType)
+	if err != nil {
+		return err
+	}
+
+	section[name] = fileValue
+
+	return nil
+}
+
+// splitIndex splits a label segment into the option name and the index of the list element (i.e. domains[0]),
+// which is -1 without index.
+func splitIndex(segment string) (string, int, error) {
+	parts := strings.SplitN(strings.TrimSuffix(segment, "]"), "[", 2)
+	if len(parts) == 1 {
+		return segment, -1, nil
+	}
+
+	index, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid index in %s: %w", segment, err)
+	}
+
+	return parts[0], index, nil
+}
+
+// fileOptionName returns the name of the option in the file format of the struct tag, the field name without it.
+func fileOptionName(field reflect.StructField, tag string) string {
+	name := strings.Split(field.Tag.Get(tag), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
+
+// parseFileValue converts a label value to the type of the option, to be written as such by the file encoders.
+// The durations are kept formatted (i.e. 15s), as Traefik reads them from the files.
+func parseFileValue(value string, rType reflect.Type) (interface{}, error) {
+	if rType == durationType {
+		return value, nil
+	}
+
+	switch rType.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(value)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, 64)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(value, 10, 64)
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(value, 64)
+
+	case reflect.Slice:
+		elements := strings.Split(value, ",")
+
+		values := make([]interface{}, 0, len(elements))
+		for _, element := range elements {
+			elementValue, err := parseFileValue(strings.TrimSpace(element), rType.Elem())
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, elementValue)
+		}
+
+		return values, nil
+
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+// The traefik entry point is created by Traefik, on the port 8080, when the insecure API or the metrics need it.
+const (
+	internalEntryPointName = "traefik"
+	internalEntryPointPort = "8080"
+)
+
+// prometheusMetrics is the data model of the Prometheus metrics in the templates.
+type prometheusMetrics struct {
+	// EntryPoint is the entry point serving the metrics.
+	EntryPoint string
+	// Port is the port of the entry point.
+	Port string
+	// ServiceMonitor is true when a Prometheus Operator ServiceMonitor is wanted.
+	ServiceMonitor bool
+}
+
+// getPrometheusMetrics returns the Prometheus metrics endpoint,
+// or nil when there is no Prometheus metrics or when they are routed manually.
+func getPrometheusMetrics(conf *static.Configuration, serviceMonitor bool) (*prometheusMetrics, error) {
+	if conf.Metrics == nil || conf.Metrics.Prometheus == nil || conf.Metrics.Prometheus.ManualRouting {
+		return nil, nil
+	}
+
+	name := prometheusEntryPoint(conf.Metrics.Prometheus)
+
+	ep, ok := conf.EntryPoints[name]
+	if !ok {
+		if name != internalEntryPointName {
+			return nil, fmt.Errorf("the Prometheus entry point %s does not exist", name)
+		}
+
+		return &prometheusMetrics{EntryPoint: name, Port: internalEntryPointPort, ServiceMonitor: serviceMonitor}, nil
+	}
+
+	_, port, err := net.SplitHostPort(ep.Address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot process the Prometheus entry point port: %w", err)
+	}
+
+	return &prometheusMetrics{EntryPoint: name, Port: port, ServiceMonitor: serviceMonitor}, nil
+}
+
+// prometheusEntryPoint returns the entry point serving the Prometheus metrics, the traefik one by default.
+func prometheusEntryPoint(prometheus *types.Prometheus) string {
+	if prometheus.EntryPoint == "" {
+		return internalEntryPointName
+	}
+
+	return prometheus.EntryPoint
+}
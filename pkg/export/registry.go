@@ -0,0 +1,284 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// Options holds the options of the exporters.
+type Options struct {
+	// Style is the layout of the CLI format.
+	Style CLIStyle
+	// Template is the path of a template overriding the one of a template format (i.e. docker and kubernetes).
+	Template string
+	// Dashboard is the router exposing the secure dashboard in the template formats.
+	Dashboard *Dashboard
+	// ServiceMonitor adds a Prometheus Operator ServiceMonitor to the kubernetes format, when the Prometheus metrics are enabled.
+	ServiceMonitor bool
+	// TracingCompanion runs the Jaeger or Zipkin backend next to Traefik in the docker and kubernetes formats.
+	TracingCompanion bool
+	// IncludeDefaults exports the options set to their default value too, to print the effective configuration.
+	IncludeDefaults bool
+	// PublishInternal publishes the traefik entry point (i.e. the insecure API and the metrics) in the docker and kubernetes formats.
+	PublishInternal bool
+}
+
+// Exporter exports a static configuration to an output format.
+type Exporter interface {
+	Export(conf *static.Configuration, opts Options, output io.Writer) error
+}
+
+// ExporterFunc is an adapter to use a function as an Exporter.
+type ExporterFunc func(conf *static.Configuration, opts Options, output io.Writer) error
+
+// Export calls f(conf, opts, output).
+func (f ExporterFunc) Export(conf *static.Configuration, opts Options, output io.Writer) error {
+	return f(conf, opts, output)
+}
+
+// Format describes an output format.
+type Format struct {
+	// Name is the value of the `--to` flag.
+	Name string
+	// Aliases are the other accepted values of the `--to` flag.
+	Aliases []string
+	// Description is the label of the format in the interactive menu.
+	Description string
+	// FileName is the name of the file written by the interactive menu, the standard output is used when empty.
+	FileName string
+	// Template is true when the format is written with a template, which the Template option overrides.
+	Template bool
+	Exporter Exporter
+}
+
+// Registry holds the output formats, in registration order.
+type Registry struct {
+	mu      sync.RWMutex
+	formats []Format
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Formats is the registry used by the baeker commands.
+// It holds the built-in formats and the bundled templates,
+// and other formats can be added with Formats.Register and Formats.RegisterTemplates.
+var Formats = newDefaultRegistry()
+
+// Register adds an output format to the registry.
+// It fails if the name or an alias is already used.
+func (r *Registry) Register(format Format) error {
+	if format.Name == "" || format.Exporter == nil {
+		return fmt.Errorf("invalid export format %q: the name and the exporter are required", format.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range append([]string{format.Name}, format.Aliases...) {
+		if r.index(name) >= 0 {
+			return fmt.Errorf("the export format %s already exists", name)
+		}
+	}
+
+	r.formats = append(r.formats, format)
+
+	return nil
+}
+
+// RegisterTemplates adds the templates as output formats.
+// A template replaces the template format with the same name, so the user templates can override the bundled ones.
+// The built-in formats (i.e. cli) are not replaced: the templates named after one of them are skipped,
+// and reported by the returned error once the other templates are registered.
+func (r *Registry) RegisterTemplates(templates *TemplateRegistry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var collisions []string
+	for _, info := range templates.List() {
+		format := templateFormat(info)
+
+		if name, ok := r.builtInName(format); ok {
+			collisions = append(collisions, fmt.Sprintf("%s (%s) is named after the built-in format %s", info.Name, info.Path, name))
+			continue
+		}
+
+		if i := r.index(format.Name); i >= 0 {
+			r.formats[i] = format
+			continue
+		}
+
+		r.formats = append(r.formats, format)
+	}
+
+	if len(collisions) > 0 {
+		return fmt.Errorf("skipped templates: %s", strings.Join(collisions, ", "))
+	}
+
+	return nil
+}
+
+// builtInName returns the name or the alias of the format which is already used by a format that is not a template.
+func (r *Registry) builtInName(format Format) (string, bool) {
+	for _, name := range append([]string{format.Name}, format.Aliases...) {
+		if i := r.index(name); i >= 0 && !r.formats[i].Template {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// Get returns the output format with the given name or alias.
+func (r *Registry) Get(name string) (Format, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.index(name)
+	if i < 0 {
+		return Format{}, false
+	}
+
+	return r.formats[i], true
+}
+
+// List returns the output formats, in registration order.
+func (r *Registry) List() []Format {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Format(nil), r.formats...)
+}
+
+// Names returns the names of the output formats, in registration order.
+func (r *Registry) Names() []string {
+	var names []string
+	for _, format := range r.List() {
+		names = append(names, format.Name)
+	}
+
+	return names
+}
+
+// Values returns the names and the aliases of the output formats, in registration order.
+// They are the values accepted by Get.
+func (r *Registry) Values() []string {
+	var values []string
+	for _, format := range r.List() {
+		values = append(values, format.Name)
+		values = append(values, format.Aliases...)
+	}
+
+	return values
+}
+
+// Export exports the configuration with the output format registered with the given name.
+func (r *Registry) Export(name string, conf *static.Configuration, opts Options, output io.Writer) error {
+	format, ok := r.Get(name)
+	if !ok {
+		return fmt.Errorf("unsupported output format: %s", name)
+	}
+
+	return format.Exporter.Export(conf, opts, output)
+}
+
+func (r *Registry) index(name string) int {
+	for i, format := range r.formats {
+		if format.Name == name || contains(format.Aliases, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// templateFormat creates an output format from a template.
+// The template can be overridden by the Template option.
+func templateFormat(info TemplateInfo) Format {
+	return Format{
+		Name:        info.Name,
+		Aliases:     info.Aliases,
+		Description: info.Description,
+		FileName:    info.Output,
+		Template:    true,
+		Exporter: ExporterFunc(func(conf *static.Configuration, opts Options, output io.Writer) error {
+			// The override applies to this export only, the registered template is kept.
+			tpl := info
+			if opts.Template != "" {
+				tpl.Path = opts.Template
+				tpl.Bundled = false
+			}
+
+			return Template(conf, tpl, opts, output)
+		}),
+	}
+}
+
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	templates, err := NewTemplateRegistry()
+	if err != nil {
+		panic(err)
+	}
+	if err := registry.RegisterTemplates(templates); err != nil {
+		panic(err)
+	}
+
+	formats := []Format{
+		{
+			Name:        "toml",
+			Description: "As a Toml File",
+			FileName:    "traefik.toml",
+			Exporter:    ExporterFunc(Toml),
+		},
+		{
+			Name:        "yaml",
+			Aliases:     []string{"yml"},
+			Description: "As a Yaml File",
+			FileName:    "traefik.yaml",
+			Exporter:    ExporterFunc(Yaml),
+		},
+		{
+			Name:        "json",
+			Description: "As a Json File",
+			FileName:    "traefik.json",
+			Exporter:    ExporterFunc(JSON),
+		},
+		{
+			Name:        "cli",
+			Description: "As CLI",
+			Exporter:    ExporterFunc(CLI),
+		},
+		{
+			Name:        "env",
+			Description: "As an Environment Variables File",
+			FileName:    "traefik.env",
+			Exporter:    ExporterFunc(Env),
+		},
+	}
+
+	for _, format := range formats {
+		if err := registry.Register(format); err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
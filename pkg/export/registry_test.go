@@ -0,0 +1,146 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+func TestFormatsRegistry(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"docker", "kubernetes", "toml", "yaml", "json", "cli", "env"}, Formats.Names())
+	assert.Equal(t, []string{"docker", "kubernetes", "crd", "k8s", "toml", "yaml", "yml", "json", "cli", "env"}, Formats.Values())
+
+	format, ok := Formats.Get("k8s")
+	require.True(t, ok)
+	assert.Equal(t, "kubernetes", format.Name)
+	assert.Equal(t, "traefik-lb-svc.yml", format.FileName)
+
+	_, ok = Formats.Get("nomad")
+	assert.False(t, ok)
+}
+
+func TestRegisterFormat(t *testing.T) {
+	t.Parallel()
+	registry := NewRegistry()
+
+	nomad := Format{
+		Name:    "nomad",
+		Aliases: []string{"hcl"},
+		Exporter: ExporterFunc(func(conf *static.Configuration, _ Options, output io.Writer) error {
+			_, err := output.Write([]byte("job \"traefik\" {}\n"))
+			return err
+		}),
+	}
+	require.NoError(t, registry.Register(nomad))
+
+	err := registry.Register(Format{Name: "hcl", Exporter: nomad.Exporter})
+	assert.EqualError(t, err, "the export format hcl already exists")
+
+	err = registry.Register(Format{Name: "empty"})
+	assert.Error(t, err)
+
+	output := new(bytes.Buffer)
+	require.NoError(t, registry.Export("hcl", &static.Configuration{}, Options{}, output))
+	assert.Equal(t, "job \"traefik\" {}\n", output.String())
+
+	err = registry.Export("docker", &static.Configuration{}, Options{}, output)
+	assert.EqualError(t, err, "unsupported output format: docker")
+}
+
+func TestRegisterTemplates(t *testing.T) {
+	t.Parallel()
+	registry := NewRegistry()
+
+	templates, err := NewTemplateRegistry(filepath.FromSlash("./fixtures/templates"))
+	require.NoError(t, err)
+
+	require.NoError(t, registry.RegisterTemplates(templates))
+	assert.Equal(t, []string{"acme-k8s", "docker", "kubernetes", "plain"}, registry.Names())
+
+	configuration := &static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: "websecure.example.com:8443"},
+		},
+	}
+
+	format, ok := registry.Get("acme-k8s")
+	require.True(t, ok)
+
+	output := new(bytes.Buffer)
+	require.NoError(t, format.Exporter.Export(configuration, Options{}, output))
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/acme-k8s.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expectedConf), output.String())
+
+	// The Template option overrides the template file.
+	format, ok = registry.Get("docker")
+	require.True(t, ok)
+
+	output.Reset()
+	err = format.Exporter.Export(configuration, Options{Template: "./fixtures/templates/acme-k8s-tpl.yml"}, output)
+	require.NoError(t, err)
+	assert.Equal(t, string(expectedConf), output.String())
+}
+
+func TestRegisterTemplatesCollidingWithBuiltInFormats(t *testing.T) {
+	t.Parallel()
+	templates, err := NewTemplateRegistry(filepath.FromSlash("./fixtures/colliding-templates"))
+	require.NoError(t, err)
+
+	registry := newDefaultRegistry()
+	err = registry.RegisterTemplates(templates)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cli ("+filepath.FromSlash("fixtures/colliding-templates/cli.tmpl")+") is named after the built-in format cli")
+	assert.Contains(t, err.Error(), "compose ("+filepath.FromSlash("fixtures/colliding-templates/compose-tpl.yml")+") is named after the built-in format yml")
+
+	// The built-in formats are kept, the other templates are registered.
+	format, ok := registry.Get("cli")
+	require.True(t, ok)
+	assert.False(t, format.Template)
+
+	format, ok = registry.Get("yml")
+	require.True(t, ok)
+	assert.Equal(t, "yaml", format.Name)
+
+	_, ok = registry.Get("compose")
+	assert.False(t, ok)
+
+	format, ok = registry.Get("ports")
+	require.True(t, ok)
+	assert.True(t, format.Template)
+}
+
+func TestTemplateOverrideIsPerExport(t *testing.T) {
+	t.Parallel()
+	registry := NewRegistry()
+
+	templates, err := NewTemplateRegistry()
+	require.NoError(t, err)
+	require.NoError(t, registry.RegisterTemplates(templates))
+
+	configuration := &static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{"web": {Address: ":8000"}},
+	}
+
+	bundled := new(bytes.Buffer)
+	require.NoError(t, registry.Export("docker", configuration, Options{}, bundled))
+
+	overridden := new(bytes.Buffer)
+	err = registry.Export("docker", configuration, Options{Template: "./fixtures/templates/plain.tmpl"}, overridden)
+	require.NoError(t, err)
+	assert.NotEqual(t, bundled.String(), overridden.String())
+
+	// The override of the previous export doesn't change the registered template.
+	output := new(bytes.Buffer)
+	require.NoError(t, registry.Export("docker", configuration, Options{}, output))
+	assert.Equal(t, bundled.String(), output.String())
+}
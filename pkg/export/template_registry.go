@@ -0,0 +1,170 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// templateSuffixes are the file name suffixes of the templates discovered in the template directories.
+var templateSuffixes = []string{"-tpl.yml", "-tpl.yaml", ".tpl", ".tmpl"}
+
+// templateHeaderRegexp matches the metadata comment at the beginning of a template.
+var templateHeaderRegexp = regexp.MustCompile(`(?s)^\s*\{\{-?\s*/\*(.*?)\*/\s*-?\}\}`)
+
+// TemplateInfo describes an export template.
+// The metadata are read from a YAML comment at the beginning of the template:
+//
+//	{{- /*
+//	name: acme-k8s
+//	aliases: [acme]
+//	description: Traefik deployment for the ACME clusters.
+//	output: traefik.yml
+//	needsPorts: true
+//	needsLabels: true
+//	*/ -}}
+type TemplateInfo struct {
+	Name string `yaml:"name"`
+	// Aliases are the other names of the template.
+	Aliases     []string `yaml:"aliases"`
+	Description string   `yaml:"description"`
+	// Output is the name of the generated file.
+	Output string `yaml:"output"`
+	// NeedsPorts computes the entry point ports, which requires valid entry point addresses.
+	NeedsPorts bool `yaml:"needsPorts"`
+	// NeedsLabels computes the non default options in the CLI format.
+	NeedsLabels bool `yaml:"needsLabels"`
+	// Path is the template file path, or the bundled file name.
+	Path string `yaml:"-"`
+	// Bundled is true for the templates bundled into the binary.
+	Bundled bool `yaml:"-"`
+}
+
+// TemplateRegistry holds the export templates, indexed by name.
+type TemplateRegistry struct {
+	templates map[string]TemplateInfo
+}
+
+// NewTemplateRegistry creates a registry with the bundled templates and the templates discovered in the given directories.
+// A template overrides the templates with the same name from the previous directories and the bundled ones.
+// The missing directories are ignored.
+func NewTemplateRegistry(dirs ...string) (*TemplateRegistry, error) {
+	registry := &TemplateRegistry{templates: make(map[string]TemplateInfo)}
+
+	for _, name := range []string{DockerTemplate, KubernetesTemplate} {
+		content, err := templates.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read the bundled template %s: %w", name, err)
+		}
+
+		info, err := parseTemplateInfo(name, content)
+		if err != nil {
+			return nil, err
+		}
+		info.Bundled = true
+
+		registry.templates[info.Name] = info
+	}
+
+	for _, dir := range dirs {
+		if err := registry.discover(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+func (r *TemplateRegistry) discover(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read the template directory %s: %w", dir, err)
+	}
+
+	for _, file := range files {
+		if file.IsDir() || templateName(file.Name()) == "" {
+			continue
+		}
+
+		filePath := filepath.Join(dir, file.Name())
+
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("cannot read the template %s: %w", filePath, err)
+		}
+
+		info, err := parseTemplateInfo(filePath, content)
+		if err != nil {
+			return err
+		}
+
+		r.templates[info.Name] = info
+	}
+
+	return nil
+}
+
+// Get returns the template registered with the given name.
+func (r *TemplateRegistry) Get(name string) (TemplateInfo, bool) {
+	info, ok := r.templates[name]
+	return info, ok
+}
+
+// List returns the registered templates, sorted by name.
+func (r *TemplateRegistry) List() []TemplateInfo {
+	infos := make([]TemplateInfo, 0, len(r.templates))
+	for _, info := range r.templates {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
+// parseTemplateInfo reads the metadata of a template.
+// Without metadata, the template is named after its file and needs both the ports and the labels.
+func parseTemplateInfo(filePath string, content []byte) (TemplateInfo, error) {
+	info := TemplateInfo{
+		Name:        templateName(filepath.Base(filePath)),
+		Path:        filePath,
+		NeedsPorts:  true,
+		NeedsLabels: true,
+	}
+
+	matches := templateHeaderRegexp.FindSubmatch(content)
+	if matches != nil {
+		if err := yaml.UnmarshalStrict(matches[1], &info); err != nil {
+			return info, fmt.Errorf("invalid metadata in the template %s: %w", filePath, err)
+		}
+	}
+
+	if info.Name == "" {
+		return info, fmt.Errorf("invalid metadata in the template %s: missing name", filePath)
+	}
+
+	return info, nil
+}
+
+// templateName returns the default name of a template from its file name, or an empty string if it is not a template.
+func templateName(fileName string) string {
+	for _, suffix := range templateSuffixes {
+		if strings.HasSuffix(fileName, suffix) {
+			return strings.TrimSuffix(fileName, suffix)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Default templates, bundled into the binary.
+const (
+	DockerTemplate     = "docker-compose-tpl.yml"
+	KubernetesTemplate = "traefik-lb-svc-tpl.yml"
+)
+
+//go:embed docker-compose-tpl.yml traefik-lb-svc-tpl.yml
+var templates embed.FS
+
+// loadTemplate loads the template file from templatePath, or the bundled defaultTemplate if templatePath is empty.
+func loadTemplate(templatePath, defaultTemplate string) (*template.Template, error) {
+	if templatePath == "" {
+		tmpl, err := template.New(defaultTemplate).Funcs(templateFuncs()).ParseFS(templates, defaultTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the bundled template %s: %w", defaultTemplate, err)
+		}
+
+		return tmpl, nil
+	}
+
+	tmpl, err := template.New(path.Base(templatePath)).Funcs(templateFuncs()).ParseFiles(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the template %s: %w", templatePath, err)
+	}
+
+	return tmpl, nil
+}
+
+// templateFuncs returns the helper functions available in the templates.
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"quote":   quote,
+		"indent":  indent,
+		"toYaml":  toYaml,
+		"default": defaultValue,
+		"join":    join,
+		"replace": replace,
+	}
+}
+
+// quote returns the value as a double-quoted string, valid in YAML and JSON.
+func quote(value interface{}) string {
+	return strconv.Quote(fmt.Sprint(value))
+}
+
+// indent indents every line of the text with the given number of spaces.
+func indent(spaces int, text string) string {
+	pad := strings.Repeat(" ", spaces)
+
+	return pad + strings.ReplaceAll(text, "\n", "\n"+pad)
+}
+
+// toYaml returns the YAML representation of the value, without the trailing new line.
+// The strings are only quoted when needed.
+func toYaml(value interface{}) (string, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode %v in YAML: %w", value, err)
+	}
+
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// defaultValue returns the given value, or the default one if the value is empty.
+func defaultValue(defaultVal, value interface{}) interface{} {
+	if value == nil {
+		return defaultVal
+	}
+
+	rValue := reflect.ValueOf(value)
+	switch rValue.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rValue.IsNil() {
+			return defaultVal
+		}
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		if rValue.Len() == 0 {
+			return defaultVal
+		}
+	default:
+		if rValue.IsZero() {
+			return defaultVal
+		}
+	}
+
+	return value
+}
+
+// join joins the elements of a list with the given separator.
+func join(separator string, list interface{}) string {
+	rValue := reflect.ValueOf(list)
+	if rValue.Kind() != reflect.Slice && rValue.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+
+	elements := make([]string, rValue.Len())
+	for i := 0; i < rValue.Len(); i++ {
+		elements[i] = fmt.Sprint(rValue.Index(i).Interface())
+	}
+
+	return strings.Join(elements, separator)
+}
+
+// replace replaces all the occurrences of old by new in the text.
+// The text is the last argument, to be used in pipelines (i.e. {{ .Value | replace "$" "$$" }}).
+func replace(old, new, text string) string {
+	return strings.ReplaceAll(text, old, new)
+}
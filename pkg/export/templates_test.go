@@ -0,0 +1,259 @@
+package export
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/jbdoumenjou/baeker/pkg/importer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/docker"
+	"github.com/traefik/traefik/v2/pkg/types"
+	"gopkg.in/yaml.v2"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		template    string
+		data        interface{}
+		expected    string
+	}{
+		{
+			description: "quote",
+			template:    `{{ quote . }}`,
+			data:        `Host("a") && Path('/b')`,
+			expected:    `"Host(\"a\") && Path('/b')"`,
+		},
+		{
+			description: "indent",
+			template:    `{{ indent 2 . }}`,
+			data:        "a: b\nc: d",
+			expected:    "  a: b\n  c: d",
+		},
+		{
+			description: "toYaml plain string",
+			template:    `{{ toYaml . }}`,
+			data:        "--entrypoints.web.address=:8000",
+			expected:    "--entrypoints.web.address=:8000",
+		},
+		{
+			description: "toYaml string to quote",
+			template:    `{{ toYaml . }}`,
+			data:        "--log.filepath=/var/log/a: b #1",
+			expected:    `'--log.filepath=/var/log/a: b #1'`,
+		},
+		{
+			description: "toYaml structure",
+			template:    `{{ toYaml . | indent 2 }}`,
+			data:        &types.TraefikLog{Level: "debug"},
+			expected:    "  level: debug",
+		},
+		{
+			description: "default with empty value",
+			template:    `{{ default "traefik:v2.4" .Image }}`,
+			data:        struct{ Image string }{},
+			expected:    "traefik:v2.4",
+		},
+		{
+			description: "default with value",
+			template:    `{{ default "traefik:v2.4" .Image }}`,
+			data:        struct{ Image string }{Image: "traefik:v2.3"},
+			expected:    "traefik:v2.3",
+		},
+		{
+			description: "default with nil pointer",
+			template:    `{{ default "none" .Log }}`,
+			data:        struct{ Log *types.TraefikLog }{},
+			expected:    "none",
+		},
+		{
+			description: "join",
+			template:    `{{ join "," . }}`,
+			data:        []string{"a", "b", "c"},
+			expected:    "a,b,c",
+		},
+		{
+			description: "replace",
+			template:    `{{ . | replace "$" "$$" }}`,
+			data:        "admin:$apr1$H6uskkkW$IgXLP6ewTrSuBkTrqE8wj/",
+			expected:    "admin:$$apr1$$H6uskkkW$$IgXLP6ewTrSuBkTrqE8wj/",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			tmpl, err := template.New(test.description).Funcs(templateFuncs()).Parse(test.template)
+			require.NoError(t, err)
+
+			output := new(bytes.Buffer)
+			err = tmpl.Execute(output, test.data)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, output.String())
+		})
+	}
+}
+
+func TestDockerExportSpecialCharacters(t *testing.T) {
+	t.Parallel()
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {Address: ":8000"},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{
+				DefaultRule: "Host(`{{ .Name }}.example.com`) && !Headers(`X-Tag`, `a<b>`)",
+				Constraints: `Label("a", "b") || Label('c', 'd')`,
+			},
+		},
+		Log: &types.TraefikLog{
+			FilePath: "/var/log/traefik: #1.log",
+		},
+	})
+	exportedConf := new(bytes.Buffer)
+	err := Docker(configuration, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/special-characters-docker-compose.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestDockerExportEscapesDollars(t *testing.T) {
+	t.Parallel()
+	hash := "admin:$2y$05$9J8o0kTV1bxGzNMm3xB8AeYbqCkpHC5I5dDOXEzDZ06lL7LpZXKau"
+	configuration := withDefaults(&static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"websecure": {Address: ":8443", HTTP: static.HTTPConfig{TLS: &static.TLSConfig{}}},
+		},
+		Providers: &static.Providers{
+			Docker: &docker.Provider{Constraints: "Label(`auth`, `" + hash + "`)"},
+		},
+		API: &static.API{Dashboard: true},
+	})
+
+	exportedConf := new(bytes.Buffer)
+	err := Docker(configuration, Options{Dashboard: &Dashboard{Host: "traefik.example.com", Users: []string{hash}}}, exportedConf)
+	require.NoError(t, err)
+
+	// Docker compose interpolates the variables, and replaces the escaped $$ by $.
+	var compose struct {
+		Services map[string]struct {
+			Command []string `yaml:"command"`
+			Labels  []string `yaml:"labels"`
+		} `yaml:"services"`
+	}
+	require.NoError(t, yaml.Unmarshal(exportedConf.Bytes(), &compose))
+
+	traefik := compose.Services["traefik"]
+	for _, value := range append(traefik.Command, traefik.Labels...) {
+		assert.NotContains(t, strings.ReplaceAll(value, "$$", ""), "$", value)
+	}
+
+	interpolated := make([]string, len(traefik.Command))
+	for i, flag := range traefik.Command {
+		interpolated[i] = strings.ReplaceAll(flag, "$$", "$")
+	}
+
+	importedConf, err := importer.CLIArgs(interpolated)
+	require.NoError(t, err)
+	assert.Equal(t, configuration.Providers.Docker.Constraints, importedConf.Providers.Docker.Constraints)
+	assert.Contains(t, traefik.Labels, "traefik.http.middlewares.dashboard-auth.basicauth.users="+strings.ReplaceAll(hash, "$", "$$"))
+}
+
+func TestCustomTemplateExport(t *testing.T) {
+	t.Parallel()
+	configuration := &static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web": {Address: ":8000"},
+		},
+		Log: &types.TraefikLog{
+			Level: "debug",
+		},
+	}
+	exportedConf := new(bytes.Buffer)
+	err := Docker(configuration, Options{Template: "./fixtures/custom-tpl.yml"}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/custom.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+}
+
+func TestTemplateRegistry(t *testing.T) {
+	t.Parallel()
+	registry, err := NewTemplateRegistry(filepath.FromSlash("./fixtures/templates"), filepath.FromSlash("./fixtures/missing"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, info := range registry.List() {
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"acme-k8s", "docker", "kubernetes", "plain"}, names)
+
+	info, ok := registry.Get("acme-k8s")
+	require.True(t, ok)
+	assert.Equal(t, TemplateInfo{
+		Name:        "acme-k8s",
+		Description: "Traefik arguments for the ACME clusters.",
+		Output:      "traefik-args.yml",
+		NeedsLabels: true,
+		Path:        filepath.Join("fixtures", "templates", "acme-k8s-tpl.yml"),
+	}, info)
+
+	info, ok = registry.Get("plain")
+	require.True(t, ok)
+	assert.True(t, info.NeedsPorts)
+	assert.True(t, info.NeedsLabels)
+
+	info, ok = registry.Get("docker")
+	require.True(t, ok)
+	assert.True(t, info.Bundled)
+	assert.Equal(t, "docker-compose.yml", info.Output)
+}
+
+func TestTemplateExport(t *testing.T) {
+	t.Parallel()
+	registry, err := NewTemplateRegistry(filepath.FromSlash("./fixtures/templates"))
+	require.NoError(t, err)
+
+	// The ports are not needed, so the entry point address is not parsed.
+	configuration := &static.Configuration{
+		EntryPoints: map[string]*static.EntryPoint{
+			"web":       {Address: ":8000"},
+			"websecure": {Address: "websecure.example.com:8443"},
+		},
+	}
+
+	info, ok := registry.Get("acme-k8s")
+	require.True(t, ok)
+
+	exportedConf := new(bytes.Buffer)
+	err = Template(configuration, info, Options{}, exportedConf)
+	require.NoError(t, err)
+
+	expectedConf, err := ioutil.ReadFile(filepath.FromSlash("./fixtures/acme-k8s.yml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expectedConf), exportedConf.String())
+
+	info, ok = registry.Get("docker")
+	require.True(t, ok)
+
+	exportedConf.Reset()
+	err = Template(&static.Configuration{EntryPoints: map[string]*static.EntryPoint{"web": {Address: ":8000"}}}, info, Options{}, exportedConf)
+	require.NoError(t, err)
+	assert.Contains(t, exportedConf.String(), "- '8000:8000'")
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// Default endpoints of the tracing backends, used by Traefik when they are not configured.
+const (
+	defaultJaegerAgent    = "127.0.0.1:6831"
+	defaultJaegerSampling = "http://localhost:5778/sampling"
+	defaultZipkinEndpoint = "http://localhost:9411/api/v2/spans"
+)
+
+// tracingCompanion is the data model of the tracing backend run next to Traefik in the templates.
+// It shares the network of Traefik, which reaches it on localhost.
+type tracingCompanion struct {
+	// Name is the name of the compose service or of the sidecar container.
+	Name  string
+	Image string
+	// Args and Env make the backend listen on the configured ports.
+	Args []string
+	Env  []envVar
+	// UIPort is the port of the web UI showing the traces.
+	UIPort string
+}
+
+// envVar is an environment variable of a container.
+type envVar struct {
+	Name  string
+	Value string
+}
+
+// getTracingCompanion returns the Jaeger all-in-one or Zipkin backend listening on the configured endpoints.
+// The endpoints must be local, as the companion shares the network of Traefik.
+func getTracingCompanion(conf *static.Configuration) (*tracingCompanion, error) {
+	if conf.Tracing == nil {
+		return nil, nil
+	}
+
+	switch {
+	case conf.Tracing.Jaeger != nil:
+		agentPort, err := localPort(withDefault(conf.Tracing.Jaeger.LocalAgentHostPort, defaultJaegerAgent))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Jaeger agent address for the tracing companion: %w", err)
+		}
+
+		samplingPort, err := localURLPort(withDefault(conf.Tracing.Jaeger.SamplingServerURL, defaultJaegerSampling))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Jaeger sampling server URL for the tracing companion: %w", err)
+		}
+
+		return &tracingCompanion{
+			Name:  "jaeger",
+			Image: "jaegertracing/all-in-one:1.21",
+			Args: []string{
+				"--processor.jaeger-compact.server-host-port=:" + agentPort,
+				"--http-server.host-port=:" + samplingPort,
+			},
+			UIPort: "16686",
+		}, nil
+
+	case conf.Tracing.Zipkin != nil:
+		port, err := localURLPort(withDefault(conf.Tracing.Zipkin.HTTPEndpoint, defaultZipkinEndpoint))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Zipkin endpoint for the tracing companion: %w", err)
+		}
+
+		return &tracingCompanion{
+			Name:   "zipkin",
+			Image:  "openzipkin/zipkin:2.23",
+			Env:    []envVar{{Name: "QUERY_PORT", Value: port}},
+			UIPort: port,
+		}, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// localURLPort returns the port of a local URL, 80 or 443 when the URL has no port.
+func localURLPort(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Port() == "" {
+		if u.Scheme == "https" {
+			return localPort(u.Hostname() + ":443")
+		}
+
+		return localPort(u.Hostname() + ":80")
+	}
+
+	return localPort(u.Host)
+}
+
+// localPort returns the port of a local address (i.e. localhost:6831).
+func localPort(address string) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", err
+	}
+
+	if host != "" && host != "localhost" {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			return "", fmt.Errorf("%s is not a local address", address)
+		}
+	}
+
+	return port, nil
+}
+
+func withDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
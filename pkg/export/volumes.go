@@ -0,0 +1,163 @@
+package export
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// acmeStorageDir is the directory mounted for the ACME storages at the root of the container (i.e. the default acme.json).
+const acmeStorageDir = "/letsencrypt"
+
+// defaultACMEStorage is the storage Traefik uses when it is not set.
+const defaultACMEStorage = "acme.json"
+
+// Sizes of the Kubernetes persistent volume claims.
+const (
+	acmeVolumeSize = "128Mi"
+	logVolumeSize  = "1Gi"
+)
+
+// volume is a directory of the Traefik container which must be persisted.
+type volume struct {
+	// Name is the volume name, usable as a Docker volume or a Kubernetes resource name.
+	Name string
+	// Path is the absolute path of the directory in the container.
+	Path string
+	// Size is the storage requested by the Kubernetes persistent volume claim.
+	Size string
+	// Logs is true when the directory holds log files, which can be rotated.
+	Logs bool
+}
+
+// getVolumes returns the directories where Traefik writes its files, which must be persisted:
+// the ACME certificates storages and the log files.
+func getVolumes(conf *static.Configuration) []volume {
+	// The directories holding log files need more space.
+	dirs := make(map[string]bool)
+
+	for _, resolver := range conf.CertificatesResolvers {
+		if resolver.ACME == nil || resolver.ACME.Storage == "" {
+			continue
+		}
+
+		dir := containerDir(resolver.ACME.Storage)
+		if _, ok := dirs[dir]; !ok && dir != "" {
+			dirs[dir] = false
+		}
+	}
+
+	var logFiles []string
+	if conf.Log != nil {
+		logFiles = append(logFiles, conf.Log.FilePath)
+	}
+	if conf.AccessLog != nil {
+		logFiles = append(logFiles, conf.AccessLog.FilePath)
+	}
+
+	for _, logFile := range logFiles {
+		if logFile == "" {
+			continue
+		}
+
+		if dir := containerDir(logFile); dir != "" {
+			dirs[dir] = true
+		}
+	}
+
+	paths := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		paths = append(paths, dir)
+	}
+	sort.Strings(paths)
+
+	var volumes []volume
+	for _, dir := range paths {
+		v := volume{Name: volumeName(dir), Path: dir, Size: acmeVolumeSize, Logs: dirs[dir]}
+		if v.Logs {
+			v.Size = logVolumeSize
+		}
+
+		volumes = append(volumes, v)
+	}
+
+	return volumes
+}
+
+// withPersistentStorages returns the configuration with the ACME storages at the root of the container
+// moved to the acmeStorageDir directory, which is mounted as the root directory cannot be.
+// Otherwise the certificates would be lost with the container, and requested again against the Let's Encrypt rate limits.
+// The given configuration is left unchanged.
+func withPersistentStorages(conf *static.Configuration) *static.Configuration {
+	var resolvers map[string]static.CertificateResolver
+	for name, resolver := range conf.CertificatesResolvers {
+		if resolver.ACME == nil {
+			continue
+		}
+
+		storage := resolver.ACME.Storage
+		if storage == "" {
+			storage = defaultACMEStorage
+		}
+
+		if containerDir(storage) != "" {
+			continue
+		}
+
+		if resolvers == nil {
+			resolvers = make(map[string]static.CertificateResolver, len(conf.CertificatesResolvers))
+			for key, value := range conf.CertificatesResolvers {
+				resolvers[key] = value
+			}
+		}
+
+		acmeConf := *resolver.ACME
+		acmeConf.Storage = path.Join(acmeStorageDir, path.Base(storage))
+		resolver.ACME = &acmeConf
+		resolvers[name] = resolver
+	}
+
+	if resolvers == nil {
+		return conf
+	}
+
+	persistentConf := *conf
+	persistentConf.CertificatesResolvers = resolvers
+
+	return &persistentConf
+}
+
+// containerDir returns the absolute directory of a file in the Traefik container, whose working directory is the root.
+// The root directory cannot be mounted, so an empty string is returned for it.
+func containerDir(filePath string) string {
+	dir := path.Dir(path.Join("/", filePath))
+	if dir == "/" {
+		return ""
+	}
+
+	return dir
+}
+
+// volumeName returns a name derived from the directory, valid for Docker and Kubernetes.
+func volumeName(dir string) string {
+	name := strings.ToLower(strings.Trim(dir, "/"))
+
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, name)
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
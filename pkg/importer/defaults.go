@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/traefik/paerser/file"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"gopkg.in/yaml.v2"
+)
+
+// decodeWithDefaults decodes the raw content of a configuration file as Traefik does:
+// each section present in the file gets its default values (i.e. exposedByDefault for the docker provider) before being filled.
+// The keys without a matching option are ignored, as by the toml, yaml and json decoders.
+// The empty sections, which the exporters write, are taken from the plain decoded configuration.
+func decodeWithDefaults(plain *static.Configuration, data map[string]interface{}) (*static.Configuration, error) {
+	pruneUnknownKeys(data, reflect.TypeOf(static.Configuration{}))
+
+	content, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode the configuration options: %w", err)
+	}
+
+	conf := &static.Configuration{}
+
+	err = file.DecodeContent(string(content), ".yaml", conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set the default values: %w", err)
+	}
+
+	addEmptySections(reflect.ValueOf(conf).Elem(), reflect.ValueOf(plain).Elem())
+
+	return conf, nil
+}
+
+// addEmptySections adds, with their default values, the sections of the plain configuration missing in the value.
+func addEmptySections(value, plainValue reflect.Value) {
+	switch {
+	case plainValue.Kind() == reflect.Ptr && plainValue.Type().Elem().Kind() == reflect.Struct:
+		if plainValue.IsNil() {
+			return
+		}
+
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+			if setter, ok := value.Interface().(defaultsSetter); ok {
+				setter.SetDefaults()
+			}
+		}
+
+		addEmptySections(value.Elem(), plainValue.Elem())
+
+	case plainValue.Kind() == reflect.Map && isSection(plainValue.Type().Elem()):
+		if plainValue.IsNil() {
+			return
+		}
+
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(value.Type()))
+		}
+
+		for _, key := range plainValue.MapKeys() {
+			elem := reflect.New(value.Type().Elem()).Elem()
+			if existing := value.MapIndex(key); existing.IsValid() {
+				elem.Set(existing)
+			}
+
+			addEmptySections(elem, plainValue.MapIndex(key))
+			value.SetMapIndex(key, elem)
+		}
+
+	case plainValue.Kind() == reflect.Struct:
+		for i := 0; i < plainValue.NumField(); i++ {
+			if plainValue.Type().Field(i).PkgPath != "" {
+				continue
+			}
+
+			addEmptySections(value.Field(i), plainValue.Field(i))
+		}
+	}
+}
+
+// isSection reports whether the type is a struct or a pointer to a struct.
+func isSection(rType reflect.Type) bool {
+	if rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	return rType.Kind() == reflect.Struct
+}
+
+type defaultsSetter interface {
+	SetDefaults()
+}
+
+// pruneUnknownKeys removes the keys of the raw data which don't match any option of the type, and the empty sections.
+// The options are matched case-insensitively, as Traefik does.
+func pruneUnknownKeys(data interface{}, rType reflect.Type) {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	switch values := data.(type) {
+	case map[string]interface{}:
+		for key, value := range values {
+			value, ok := pruneKey(key, value, rType)
+			if !ok || isEmptyMap(value) {
+				delete(values, key)
+				continue
+			}
+
+			values[key] = value
+		}
+
+	case map[interface{}]interface{}:
+		for key, value := range values {
+			value, ok := pruneKey(fmt.Sprint(key), value, rType)
+			if !ok || isEmptyMap(value) {
+				delete(values, key)
+				continue
+			}
+
+			values[key] = value
+		}
+
+	case []interface{}:
+		if rType.Kind() == reflect.Slice {
+			for _, value := range values {
+				pruneUnknownKeys(value, rType.Elem())
+			}
+		}
+
+	case []map[string]interface{}:
+		if rType.Kind() == reflect.Slice {
+			for _, value := range values {
+				pruneUnknownKeys(value, rType.Elem())
+			}
+		}
+	}
+}
+
+// isEmptyMap reports whether the raw value is an empty section, which the file decoder rejects.
+func isEmptyMap(value interface{}) bool {
+	switch values := value.(type) {
+	case map[string]interface{}:
+		return len(values) == 0
+	case map[interface{}]interface{}:
+		return len(values) == 0
+	default:
+		return false
+	}
+}
+
+// pruneKey prunes the value of a key, and reports whether the key matches an option of the type.
+// The json durations, in nanoseconds, are converted as the file decoder reads the numbers as seconds.
+func pruneKey(key string, value interface{}, rType reflect.Type) (interface{}, bool) {
+	switch rType.Kind() {
+	case reflect.Map:
+		pruneUnknownKeys(value, rType.Elem())
+		return value, true
+
+	case reflect.Struct:
+		field, ok := rType.FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, key)
+		})
+		if !ok || field.PkgPath != "" || field.Tag.Get("file") == "-" {
+			return nil, false
+		}
+
+		if number, ok := value.(json.Number); ok && field.Type == reflect.TypeOf(ptypes.Duration(0)) {
+			nanoseconds, err := number.Int64()
+			if err == nil {
+				return time.Duration(nanoseconds).String(), true
+			}
+		}
+
+		pruneUnknownKeys(value, field.Type)
+		return value, true
+
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,66 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Source formats.
+const (
+	FormatYaml = "yaml"
+	FormatToml = "toml"
+	FormatCLI  = "cli"
+	FormatEnv  = "env"
+	FormatJSON = "json"
+)
+
+var (
+	envLineRegexp  = regexp.MustCompile(`^(export\s+)?[A-Z_][A-Z0-9_]*=`)
+	tomlLineRegexp = regexp.MustCompile(`^(\[.+]|[A-Za-z0-9_.-]+\s*=)`)
+)
+
+// DetectFormat detects the format of a static configuration source.
+// The format is detected from the file extension of the registered formats and, failing that, from the content.
+func DetectFormat(filePath string, content []byte) string {
+	if format := Formats.formatFromExtension(filePath); format != "" {
+		return format
+	}
+
+	return formatFromContent(content)
+}
+
+func formatFromContent(content []byte) string {
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return FormatJSON
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// The flags can be written as a yaml list, as in a docker-compose command block.
+		flag := strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-")), `"'`)
+
+		switch {
+		case envLineRegexp.MatchString(line):
+			return FormatEnv
+		case strings.HasPrefix(line, "--"), strings.HasPrefix(flag, "--"), strings.HasPrefix(line, "traefik "):
+			return FormatCLI
+		case tomlLineRegexp.MatchString(line):
+			return FormatToml
+		case line == "command:":
+			// docker-compose command block, the flags are on the next lines.
+			continue
+		default:
+			return FormatYaml
+		}
+	}
+
+	return FormatYaml
+}
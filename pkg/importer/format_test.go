@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		content     string
+		expected    string
+	}{
+		{
+			description: "yaml extension",
+			filePath:    "traefik.yml",
+			expected:    FormatYaml,
+		},
+		{
+			description: "toml extension",
+			filePath:    "traefik.TOML",
+			expected:    FormatToml,
+		},
+		{
+			description: "json extension",
+			filePath:    "traefik.json",
+			expected:    FormatJSON,
+		},
+		{
+			description: "dot env file",
+			filePath:    "/etc/traefik/.env",
+			expected:    FormatEnv,
+		},
+		{
+			description: "extension wins over content",
+			filePath:    "traefik.yml",
+			content:     "[log]\n  level = \"debug\"",
+			expected:    FormatYaml,
+		},
+		{
+			description: "yaml content",
+			content:     "log:\n  level: debug\n",
+			expected:    FormatYaml,
+		},
+		{
+			description: "toml content",
+			content:     "# comment\n[log]\n  level = \"debug\"",
+			expected:    FormatToml,
+		},
+		{
+			description: "toml key content",
+			content:     "checkNewVersion = true",
+			expected:    FormatToml,
+		},
+		{
+			description: "json content",
+			content:     "  {\"log\": {\"level\": \"debug\"}}",
+			expected:    FormatJSON,
+		},
+		{
+			description: "cli content",
+			content:     "--log.level=debug --providers.docker",
+			expected:    FormatCLI,
+		},
+		{
+			description: "docker-compose command block",
+			content:     "command:\n  - \"--log.level=debug\"\n",
+			expected:    FormatCLI,
+		},
+		{
+			description: "env content",
+			content:     "export TRAEFIK_LOG_LEVEL=debug",
+			expected:    FormatEnv,
+		},
+		{
+			description: "empty content",
+			expected:    FormatYaml,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, DetectFormat(test.filePath, []byte(test.content)))
+		})
+	}
+}
+
+func TestDetectFormatFromFixtures(t *testing.T) {
+	t.Parallel()
+	testcases := map[string]string{
+		"./fixtures/static.toml": FormatToml,
+		"./fixtures/static.yml":  FormatYaml,
+		"./fixtures/static.cli":  FormatCLI,
+		"./fixtures/command.cli": FormatCLI,
+		"./fixtures/static.env":  FormatEnv,
+		"./fixtures/empty.toml":  FormatYaml,
+		"./fixtures/docker.env":  FormatEnv,
+	}
+
+	for filePath, expected := range testcases {
+		content, err := ioutil.ReadFile(filepath.FromSlash(filePath))
+		require.NoError(t, err)
+
+		assert.Equal(t, expected, DetectFormat("", content), filePath)
+	}
+}
@@ -0,0 +1,283 @@
+// Package importer reads a Traefik static configuration from yaml, toml, json, CLI flags or environment variables.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"github.com/traefik/paerser/env"
+	"github.com/traefik/paerser/flag"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"gopkg.in/yaml.v2"
+)
+
+// Toml import toml conf to static configuration.
+// As in Traefik, the sections of the file get their default values.
+func Toml(input io.Reader) (*static.Configuration, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read toml file: %w", err)
+	}
+
+	conf := &static.Configuration{}
+
+	_, err = toml.Decode(string(content), conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from toml file: %w", err)
+	}
+
+	data := make(map[string]interface{})
+	if _, err = toml.Decode(string(content), &data); err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from toml file: %w", err)
+	}
+
+	return decodeWithDefaults(conf, data)
+}
+
+// Yaml import yaml conf to static configuration.
+// As in Traefik, the sections of the file get their default values.
+func Yaml(input io.Reader) (*static.Configuration, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read yaml file: %w", err)
+	}
+
+	conf := &static.Configuration{}
+
+	err = yaml.Unmarshal(content, conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from yaml file: %w", err)
+	}
+
+	data := make(map[string]interface{})
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from yaml file: %w", err)
+	}
+
+	return decodeWithDefaults(conf, data)
+}
+
+// JSON import json conf to static configuration.
+// As in Traefik, the sections of the file get their default values.
+func JSON(input io.Reader) (*static.Configuration, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read json file: %w", err)
+	}
+
+	conf := &static.Configuration{}
+
+	err = json.Unmarshal(content, conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from json file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	data := make(map[string]interface{})
+	if err = decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from json file: %w", err)
+	}
+
+	return decodeWithDefaults(conf, data)
+}
+
+// CLI import a CLI flags conf (i.e. `--entrypoints.web.address=:8000 --providers.docker`) to static configuration.
+// The flags can be split on several lines, and can be prefixed by yaml list markers
+// as in the command block of a docker-compose file.
+func CLI(input io.Reader) (*static.Configuration, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cli flags: %w", err)
+	}
+
+	args, err := splitArgs(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("cannot split cli flags: %w", err)
+	}
+
+	return CLIArgs(args)
+}
+
+// CLIArgs import a list of CLI flags to static configuration.
+func CLIArgs(args []string) (*static.Configuration, error) {
+	flags := cleanArgs(args)
+	if len(args) > 0 && len(flags) == 0 {
+		return nil, errors.New("cannot decode static configuration from cli flags: no flag found")
+	}
+
+	conf := &static.Configuration{}
+	err := flag.Decode(flags, conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from cli flags: %w", err)
+	}
+
+	return conf, nil
+}
+
+// Env import a list of environment variables (i.e. `TRAEFIK_ENTRYPOINTS_WEB_ADDRESS=:8000`) to static configuration.
+// The variables not related to the Traefik static configuration are ignored,
+// so the current process environment (os.Environ()) can be used as is.
+func Env(environ []string) (*static.Configuration, error) {
+	conf := &static.Configuration{}
+
+	vars := env.FindPrefixedEnvVars(environ, env.DefaultNamePrefix, conf)
+	if len(vars) == 0 {
+		return conf, nil
+	}
+
+	err := env.Decode(vars, env.DefaultNamePrefix, conf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from environment variables: %w", err)
+	}
+
+	return conf, nil
+}
+
+// EnvFile import a .env file conf to static configuration.
+func EnvFile(input io.Reader) (*static.Configuration, error) {
+	environ, err := readEnvFile(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read env file: %w", err)
+	}
+
+	return Env(environ)
+}
+
+// readEnvFile reads the KEY=VALUE pairs of a .env file.
+// Empty lines and comments are skipped, the `export` keyword and the quotes around the values are removed.
+func readEnvFile(input io.Reader) ([]string, error) {
+	var environ []string
+
+	scanner := bufio.NewScanner(input)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		n := strings.SplitN(line, "=", 2)
+		if len(n) != 2 || strings.TrimSpace(n[0]) == "" {
+			return nil, fmt.Errorf("invalid line %d: %q", lineNumber, line)
+		}
+
+		environ = append(environ, strings.TrimSpace(n[0])+"="+unquoteEnvValue(strings.TrimSpace(n[1])))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return environ, nil
+}
+
+func unquoteEnvValue(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+
+	switch {
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1]
+	case value[0] == '"' && value[len(value)-1] == '"':
+		return strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\$`, `$`).Replace(value[1 : len(value)-1])
+	default:
+		return value
+	}
+}
+
+// cleanArgs removes everything which is not related to the flags:
+// the leading words (command name, yaml key, ...) and the yaml list markers.
+func cleanArgs(args []string) []string {
+	var cleaned []string
+	for _, arg := range args {
+		if arg == "-" {
+			continue
+		}
+
+		if len(cleaned) == 0 && !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		cleaned = append(cleaned, arg)
+	}
+
+	return cleaned
+}
+
+// splitArgs splits a command line into arguments, following the POSIX shell quoting rules.
+func splitArgs(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			// Within double quotes, the backslash only escapes a few characters.
+			if quote == '"' && !strings.ContainsRune("$`\"\\\n", r) {
+				current.WriteRune('\\')
+			}
+			// A backslash followed by a newline is a line continuation.
+			if r != '\n' {
+				current.WriteRune(r)
+				inArg = true
+			}
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape sequence")
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
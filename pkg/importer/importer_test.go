@@ -0,0 +1,481 @@
+package importer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/provider/docker"
+	"github.com/traefik/traefik/v2/pkg/provider/file"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestImportToml(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		err         bool
+	}{
+		{
+			description: "empty",
+			filePath:    "./fixtures/empty.toml",
+			expected:    &static.Configuration{},
+		},
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.toml",
+			expected: &static.Configuration{
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use unsopported format (yaml instead of toml)",
+			filePath:    "./fixtures/static.yml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := Toml(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestImportYaml(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		err         bool
+	}{
+		{
+			description: "empty",
+			filePath:    "./fixtures/empty.yml",
+			expected:    &static.Configuration{},
+		},
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.yml",
+			expected: &static.Configuration{
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use unsopported format (toml instead of yaml)",
+			filePath:    "./fixtures/static.toml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := Yaml(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		err         bool
+	}{
+		{
+			description: "empty",
+			filePath:    "./fixtures/empty.json",
+			expected:    &static.Configuration{},
+		},
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.json",
+			expected: &static.Configuration{
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use unsopported format (yaml instead of json)",
+			filePath:    "./fixtures/static.yml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := JSON(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestImportFileDefaults(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		importer    func(io.Reader) (*static.Configuration, error)
+		content     string
+	}{
+		{
+			description: "toml",
+			importer:    Toml,
+			content: `[entryPoints.web]
+  address = ":8000"
+[providers.docker]
+  exposedByDefault = false
+  unknown = true
+[unknown]
+  key = "value"
+`,
+		},
+		{
+			description: "yaml",
+			importer:    Yaml,
+			content: `entryPoints:
+  web:
+    address: :8000
+providers:
+  docker:
+    exposedByDefault: false
+    unknown: true
+unknown:
+  key: value
+`,
+		},
+		{
+			description: "json",
+			importer:    JSON,
+			content:     `{"entryPoints": {"web": {"address": ":8000"}}, "providers": {"docker": {"exposedByDefault": false, "unknown": true}}, "unknown": {"key": "value"}}`,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			conf, err := test.importer(strings.NewReader(test.content))
+			require.NoError(t, err)
+
+			dockerProvider := newDefaultDockerProvider()
+			dockerProvider.ExposedByDefault = false
+
+			expected := &static.Configuration{
+				EntryPoints: static.EntryPoints{"web": newDefaultEntryPoint(":8000")},
+				Providers:   &static.Providers{Docker: dockerProvider},
+			}
+
+			assert.Equal(t, expected, conf)
+		})
+	}
+}
+
+func TestImportJSONExportedSections(t *testing.T) {
+	t.Parallel()
+
+	// The json exporter writes the durations in nanoseconds and the empty sections as empty objects.
+	content := `{"entryPoints": {"web": {"address": ":8000", "forwardedHeaders": {}}}, "providers": {"docker": {"swarmModeRefreshSeconds": 30000000000}}}`
+
+	conf, err := JSON(strings.NewReader(content))
+	require.NoError(t, err)
+
+	entryPoint := newDefaultEntryPoint(":8000")
+	entryPoint.ForwardedHeaders = &static.ForwardedHeaders{}
+
+	dockerProvider := newDefaultDockerProvider()
+	dockerProvider.SwarmModeRefreshSeconds = ptypes.Duration(30 * time.Second)
+
+	expected := &static.Configuration{
+		EntryPoints: static.EntryPoints{"web": entryPoint},
+		Providers:   &static.Providers{Docker: dockerProvider},
+	}
+
+	assert.Equal(t, expected, conf)
+}
+
+func TestImportCLI(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		err         bool
+	}{
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.cli",
+			expected: &static.Configuration{
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use docker-compose command block",
+			filePath:    "./fixtures/command.cli",
+			expected: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"web":       newDefaultEntryPoint(":8000"),
+					"websecure": newDefaultEntryPoint(":8443"),
+				},
+				Providers: &static.Providers{
+					Docker: newDefaultDockerProvider(),
+				},
+				Log: &types.TraefikLog{
+					Level:  "DEBUG",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use unsopported format (yaml instead of cli)",
+			filePath:    "./fixtures/static.yml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := CLI(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		line        string
+		expected    []string
+		err         bool
+	}{
+		{
+			description: "simple flags",
+			line:        "--entrypoints.web.address=:8000 --providers.docker",
+			expected:    []string{"--entrypoints.web.address=:8000", "--providers.docker"},
+		},
+		{
+			description: "quoted values",
+			line:        `--providers.docker.defaultrule='Host(` + "`{{ .Name }}`" + `)' "--log.filepath=/var/log/my traefik.log"`,
+			expected:    []string{"--providers.docker.defaultrule=Host(`{{ .Name }}`)", "--log.filepath=/var/log/my traefik.log"},
+		},
+		{
+			description: "line continuations",
+			line:        "traefik \\\n  --log.level=DEBUG \\\n  --providers.docker\n",
+			expected:    []string{"traefik", "--log.level=DEBUG", "--providers.docker"},
+		},
+		{
+			description: "escaped characters",
+			line:        `--a=b\ c "--d=\"e\" \f"`,
+			expected:    []string{"--a=b c", `--d="e" \f`},
+		},
+		{
+			description: "unterminated quote",
+			line:        `--a='b`,
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			args, err := splitArgs(test.line)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, args)
+		})
+	}
+}
+
+func newDefaultEntryPoint(address string) *static.EntryPoint {
+	ep := &static.EntryPoint{}
+	ep.SetDefaults()
+	ep.Address = address
+
+	return ep
+}
+
+func newDefaultDockerProvider() *docker.Provider {
+	provider := &docker.Provider{}
+	provider.SetDefaults()
+
+	return provider
+}
+
+func TestImportEnv(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		environ     []string
+		expected    *static.Configuration
+		err         bool
+	}{
+		{
+			description: "empty",
+			expected:    &static.Configuration{},
+		},
+		{
+			description: "Use Traefik variables",
+			environ:     []string{"TRAEFIK_LOG_LEVEL=DEBUG", "TRAEFIK_PROVIDERS_DOCKER=true", "HOME=/root"},
+			expected: &static.Configuration{
+				Providers: &static.Providers{
+					Docker: newDefaultDockerProvider(),
+				},
+				Log: &types.TraefikLog{
+					Level:  "DEBUG",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use invalid value",
+			environ:     []string{"TRAEFIK_PROVIDERS_DOCKER_WATCH=maybe"},
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			conf, err := Env(test.environ)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestImportEnvFile(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		err         bool
+	}{
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.env",
+			expected: &static.Configuration{
+				EntryPoints: static.EntryPoints{
+					"web": newDefaultEntryPoint(":8000"),
+				},
+				Providers: &static.Providers{
+					File: &file.Provider{Directory: "/etc/traefik/conf", Watch: true},
+				},
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use unsopported format (toml instead of env)",
+			filePath:    "./fixtures/static.toml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := EnvFile(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+// Options holds the options of the importers.
+type Options struct {
+	// Strict reports the unknown keys of the source as errors, when the format supports it.
+	Strict bool
+}
+
+// Importer imports a static configuration from a source format.
+type Importer interface {
+	Import(input io.Reader, opts Options) (*static.Configuration, error)
+}
+
+// ImporterFunc is an adapter to use a function as an Importer.
+type ImporterFunc func(input io.Reader, opts Options) (*static.Configuration, error)
+
+// Import calls f(input, opts).
+func (f ImporterFunc) Import(input io.Reader, opts Options) (*static.Configuration, error) {
+	return f(input, opts)
+}
+
+// Format describes a source format.
+type Format struct {
+	// Name is the value of the `--from` flag.
+	Name string
+	// Aliases are the other accepted values of the `--from` flag.
+	Aliases []string
+	// Extensions are the file extensions (i.e. ".yml") used to detect the format of a file.
+	Extensions []string
+	Importer   Importer
+}
+
+// Registry holds the source formats, in registration order.
+type Registry struct {
+	mu      sync.RWMutex
+	formats []Format
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Formats is the registry used by the baeker commands.
+// It holds the built-in formats, and other formats can be added with Formats.Register.
+var Formats = newDefaultRegistry()
+
+// Register adds a source format to the registry.
+// It fails if the name or an alias is already used.
+func (r *Registry) Register(format Format) error {
+	if format.Name == "" || format.Importer == nil {
+		return fmt.Errorf("invalid import format %q: the name and the importer are required", format.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range append([]string{format.Name}, format.Aliases...) {
+		if r.index(name) >= 0 {
+			return fmt.Errorf("the import format %s already exists", name)
+		}
+	}
+
+	r.formats = append(r.formats, format)
+
+	return nil
+}
+
+// Get returns the source format with the given name or alias.
+func (r *Registry) Get(name string) (Format, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.index(name)
+	if i < 0 {
+		return Format{}, false
+	}
+
+	return r.formats[i], true
+}
+
+// List returns the source formats, in registration order.
+func (r *Registry) List() []Format {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Format(nil), r.formats...)
+}
+
+// Names returns the names of the source formats, in registration order.
+func (r *Registry) Names() []string {
+	var names []string
+	for _, format := range r.List() {
+		names = append(names, format.Name)
+	}
+
+	return names
+}
+
+// Values returns the names and the aliases of the source formats, in registration order.
+// They are the values accepted by Get.
+func (r *Registry) Values() []string {
+	var values []string
+	for _, format := range r.List() {
+		values = append(values, format.Name)
+		values = append(values, format.Aliases...)
+	}
+
+	return values
+}
+
+// Import imports the input with the source format registered with the given name.
+func (r *Registry) Import(name string, input io.Reader, opts Options) (*static.Configuration, error) {
+	format, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unsupported source format: %s", name)
+	}
+
+	return format.Importer.Import(input, opts)
+}
+
+// formatFromExtension returns the name of the source format matching the file extension, or an empty string.
+func (r *Registry) formatFromExtension(filePath string) string {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext == "" {
+		return ""
+	}
+
+	for _, format := range r.List() {
+		for _, formatExt := range format.Extensions {
+			if formatExt == ext {
+				return format.Name
+			}
+		}
+	}
+
+	return ""
+}
+
+func (r *Registry) index(name string) int {
+	for i, format := range r.formats {
+		if format.Name == name || contains(format.Aliases, name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	formats := []Format{
+		{
+			Name:       FormatYaml,
+			Aliases:    []string{"yml"},
+			Extensions: []string{".yml", ".yaml"},
+			Importer: ImporterFunc(func(input io.Reader, opts Options) (*static.Configuration, error) {
+				if opts.Strict {
+					return YamlStrict(input)
+				}
+				return Yaml(input)
+			}),
+		},
+		{
+			Name:       FormatToml,
+			Extensions: []string{".toml"},
+			Importer: ImporterFunc(func(input io.Reader, opts Options) (*static.Configuration, error) {
+				if opts.Strict {
+					return TomlStrict(input)
+				}
+				return Toml(input)
+			}),
+		},
+		{
+			Name:       FormatJSON,
+			Extensions: []string{".json"},
+			Importer: ImporterFunc(func(input io.Reader, _ Options) (*static.Configuration, error) {
+				return JSON(input)
+			}),
+		},
+		{
+			Name:       FormatCLI,
+			Extensions: []string{".cli", ".sh"},
+			Importer: ImporterFunc(func(input io.Reader, _ Options) (*static.Configuration, error) {
+				return CLI(input)
+			}),
+		},
+		{
+			Name:       FormatEnv,
+			Extensions: []string{".env"},
+			Importer: ImporterFunc(func(input io.Reader, _ Options) (*static.Configuration, error) {
+				return EnvFile(input)
+			}),
+		},
+	}
+
+	for _, format := range formats {
+		if err := registry.Register(format); err != nil {
+			panic(err)
+		}
+	}
+
+	return registry
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+)
+
+func TestFormatsRegistry(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"yaml", "toml", "json", "cli", "env"}, Formats.Names())
+	assert.Equal(t, []string{"yaml", "yml", "toml", "json", "cli", "env"}, Formats.Values())
+
+	format, ok := Formats.Get("yml")
+	require.True(t, ok)
+	assert.Equal(t, FormatYaml, format.Name)
+
+	_, ok = Formats.Get("hcl")
+	assert.False(t, ok)
+
+	file, err := os.Open(filepath.FromSlash("./fixtures/misspelled.yml"))
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	_, err = Formats.Import(FormatYaml, file, Options{Strict: true})
+	assert.Error(t, err)
+
+	_, err = Formats.Import("hcl", strings.NewReader(""), Options{})
+	assert.EqualError(t, err, "unsupported source format: hcl")
+}
+
+func TestRegisterFormat(t *testing.T) {
+	t.Parallel()
+	registry := NewRegistry()
+
+	properties := Format{
+		Name:       "properties",
+		Aliases:    []string{"props"},
+		Extensions: []string{".properties"},
+		Importer: ImporterFunc(func(input io.Reader, _ Options) (*static.Configuration, error) {
+			return EnvFile(input)
+		}),
+	}
+	require.NoError(t, registry.Register(properties))
+
+	err := registry.Register(Format{Name: "props", Importer: properties.Importer})
+	assert.EqualError(t, err, "the import format props already exists")
+
+	err = registry.Register(Format{Name: "empty"})
+	assert.Error(t, err)
+
+	assert.Equal(t, "properties", registry.formatFromExtension("traefik.properties"))
+	assert.Equal(t, "", registry.formatFromExtension("traefik"))
+
+	conf, err := registry.Import("props", strings.NewReader("TRAEFIK_LOG_LEVEL=DEBUG"), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG", conf.Log.Level)
+}
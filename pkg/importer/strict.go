@@ -0,0 +1,254 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// UnknownKey is a key of a configuration file which does not match any option.
+type UnknownKey struct {
+	Path string
+	// Line and Column are the position of the key in the file, they are zero when unknown.
+	Line   int
+	Column int
+}
+
+func (k UnknownKey) String() string {
+	if k.Line == 0 {
+		return k.Path
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s", k.Line, k.Column, k.Path)
+}
+
+// UnknownKeysError is returned by the strict importers when the configuration contains unknown keys.
+type UnknownKeysError struct {
+	Keys []UnknownKey
+}
+
+func (e *UnknownKeysError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%d unknown key(s):", len(e.Keys)))
+	for _, key := range e.Keys {
+		sb.WriteString("\n  " + key.String())
+	}
+
+	return sb.String()
+}
+
+// YamlStrict import yaml conf to static configuration, and reports the unknown keys as an UnknownKeysError.
+func YamlStrict(input io.Reader) (*static.Configuration, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read yaml file: %w", err)
+	}
+
+	conf, err := Yaml(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	root := &yamlv3.Node{}
+	err = yamlv3.Unmarshal(content, root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from yaml file: %w", err)
+	}
+
+	var unknownKeys []UnknownKey
+	findYamlUnknownKeys(root, reflect.TypeOf(conf), nil, &unknownKeys)
+
+	if len(unknownKeys) > 0 {
+		return nil, &UnknownKeysError{Keys: unknownKeys}
+	}
+
+	return conf, nil
+}
+
+// findYamlUnknownKeys browses the yaml nodes and the configuration type together, to find the keys without a matching field.
+func findYamlUnknownKeys(node *yamlv3.Node, rType reflect.Type, path []string, unknownKeys *[]UnknownKey) {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		for _, child := range node.Content {
+			findYamlUnknownKeys(child, rType, path, unknownKeys)
+		}
+		return
+	case yamlv3.AliasNode:
+		findYamlUnknownKeys(node.Alias, rType, path, unknownKeys)
+		return
+	}
+
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	switch {
+	case rType.Kind() == reflect.Struct && node.Kind == yamlv3.MappingNode:
+		fields := yamlFields(rType)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				findYamlUnknownKeys(value, rType, path, unknownKeys)
+				continue
+			}
+
+			fieldType, ok := fields[strings.ToLower(key.Value)]
+			if !ok {
+				*unknownKeys = append(*unknownKeys, UnknownKey{
+					Path:   strings.Join(append(path, key.Value), "."),
+					Line:   key.Line,
+					Column: key.Column,
+				})
+				continue
+			}
+
+			findYamlUnknownKeys(value, fieldType, append(path, key.Value), unknownKeys)
+		}
+	case rType.Kind() == reflect.Map && node.Kind == yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			findYamlUnknownKeys(node.Content[i+1], rType.Elem(), append(path, node.Content[i].Value), unknownKeys)
+		}
+	case rType.Kind() == reflect.Slice && node.Kind == yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			findYamlUnknownKeys(item, rType.Elem(), append(path, fmt.Sprintf("[%d]", i)), unknownKeys)
+		}
+	}
+}
+
+// yamlFields returns the types of the struct fields, indexed by their lowercase yaml name:
+// as in Traefik, the keys are matched case-insensitively.
+func yamlFields(rType reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+
+		if len(tag) > 1 && tag[1] == "inline" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct {
+				for name, inlineType := range yamlFields(fieldType) {
+					fields[name] = inlineType
+				}
+			}
+			continue
+		}
+
+		name := tag[0]
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[strings.ToLower(name)] = field.Type
+	}
+
+	return fields
+}
+
+// TomlStrict import toml conf to static configuration, and reports the unknown keys as an UnknownKeysError.
+func TomlStrict(input io.Reader) (*static.Configuration, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read toml file: %w", err)
+	}
+
+	conf, err := Toml(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	metaData, err := toml.Decode(string(content), &static.Configuration{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode static configuration from toml file: %w", err)
+	}
+
+	var unknownKeys []UnknownKey
+	for _, key := range metaData.Undecoded() {
+		// The children of an unknown key are unknown too, only the parent is reported.
+		if len(unknownKeys) > 0 && strings.HasPrefix(key.String(), unknownKeys[len(unknownKeys)-1].Path+".") {
+			continue
+		}
+
+		line, column := locateTomlKey(content, key)
+		unknownKeys = append(unknownKeys, UnknownKey{Path: key.String(), Line: line, Column: column})
+	}
+
+	if len(unknownKeys) > 0 {
+		return nil, &UnknownKeysError{Keys: unknownKeys}
+	}
+
+	return conf, nil
+}
+
+// locateTomlKey finds the position of a key in a toml content.
+// The toml decoder doesn't provide the positions, so the lines are browsed while following the current table.
+func locateTomlKey(content []byte, key toml.Key) (int, int) {
+	target := strings.ToLower(key.String())
+
+	var table string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		column := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+
+		if strings.HasPrefix(trimmed, "[") {
+			end := strings.LastIndex(trimmed, "]")
+			if end < 0 {
+				continue
+			}
+
+			table = normalizeTomlKey(strings.Trim(trimmed[:end+1], "[]"))
+			if table == target {
+				return lineNumber, column
+			}
+			continue
+		}
+
+		n := strings.SplitN(trimmed, "=", 2)
+		if len(n) != 2 {
+			continue
+		}
+
+		name := normalizeTomlKey(n[0])
+		if table != "" {
+			name = table + "." + name
+		}
+
+		if name == target {
+			return lineNumber, column
+		}
+	}
+
+	return 0, 0
+}
+
+func normalizeTomlKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.ToLower(strings.Trim(strings.TrimSpace(part), `"'`))
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,185 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v2/pkg/config/static"
+	"github.com/traefik/traefik/v2/pkg/types"
+)
+
+func TestImportYamlStrict(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		unknownKeys []UnknownKey
+		err         bool
+	}{
+		{
+			description: "empty",
+			filePath:    "./fixtures/empty.yml",
+			expected:    &static.Configuration{},
+		},
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.yml",
+			expected: &static.Configuration{
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use misspelled keys",
+			filePath:    "./fixtures/misspelled.yml",
+			unknownKeys: []UnknownKey{
+				{Path: "entrypoint", Line: 1, Column: 1},
+			},
+		},
+		{
+			description: "Use unsopported format (toml instead of yaml)",
+			filePath:    "./fixtures/static.toml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := YamlStrict(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if test.unknownKeys != nil {
+				var unknownKeysErr *UnknownKeysError
+				require.True(t, errors.As(err, &unknownKeysErr))
+				assert.Equal(t, test.unknownKeys, unknownKeysErr.Keys)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestImportTomlStrict(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		filePath    string
+		expected    *static.Configuration
+		unknownKeys []UnknownKey
+		err         bool
+	}{
+		{
+			description: "empty",
+			filePath:    "./fixtures/empty.toml",
+			expected:    &static.Configuration{},
+		},
+		{
+			description: "Use valid file",
+			filePath:    "./fixtures/static.toml",
+			expected: &static.Configuration{
+				Log: &types.TraefikLog{
+					Level:  "debug",
+					Format: "common",
+				},
+			},
+		},
+		{
+			description: "Use misspelled keys",
+			filePath:    "./fixtures/misspelled.toml",
+			unknownKeys: []UnknownKey{
+				{Path: "entrypoint", Line: 1, Column: 1},
+				{Path: "providers.docker.endpoints", Line: 7, Column: 3},
+			},
+		},
+		{
+			description: "Use unsopported format (yaml instead of toml)",
+			filePath:    "./fixtures/static.yml",
+			err:         true,
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			confReader, err := os.Open(filepath.FromSlash(test.filePath))
+			require.NoError(t, err)
+
+			conf, err := TomlStrict(confReader)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+
+			if test.unknownKeys != nil {
+				var unknownKeysErr *UnknownKeysError
+				require.True(t, errors.As(err, &unknownKeysErr))
+				assert.Equal(t, test.unknownKeys, unknownKeysErr.Keys)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, conf)
+		})
+	}
+}
+
+func TestStrictModesAgreeOnKeyCase(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		description string
+		importer    func(io.Reader) (*static.Configuration, error)
+		strict      func(io.Reader) (*static.Configuration, error)
+		content     string
+	}{
+		{
+			description: "yaml",
+			importer:    Yaml,
+			strict:      YamlStrict,
+			content:     "providers:\n  Docker:\n    exposedbydefault: false\n",
+		},
+		{
+			description: "toml",
+			importer:    Toml,
+			strict:      TomlStrict,
+			content:     "[providers.Docker]\n  exposedbydefault = false\n",
+		},
+	}
+
+	for _, test := range testcases {
+		test := test
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			conf, err := test.importer(strings.NewReader(test.content))
+			require.NoError(t, err)
+			require.NotNil(t, conf.Providers.Docker)
+			assert.False(t, conf.Providers.Docker.ExposedByDefault)
+
+			strictConf, err := test.strict(strings.NewReader(test.content))
+			require.NoError(t, err)
+			assert.Equal(t, conf, strictConf)
+		})
+	}
+}
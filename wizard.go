@@ -0,0 +1,715 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/jbdoumenjou/baeker/pkg/builder"
+	"github.com/jbdoumenjou/baeker/pkg/export"
+	"github.com/traefik/traefik/v2/pkg/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// wizardAnswers holds the answers of the interactive wizard.
+type wizardAnswers struct {
+	// Format is the description of the chosen output format.
+	Format string `survey:"format"`
+	// Redirect redirects the web entry point to the websecure one.
+	Redirect bool `survey:"redirect"`
+	// ACME holds the Let's Encrypt answers, nil when no certificate resolver is wanted.
+	ACME *acmeAnswers
+	// Dashboard is the dashboard mode, one of the dashboard* constants.
+	Dashboard string
+	// DashboardRouter exposes the dashboard in secure mode, nil otherwise.
+	DashboardRouter *export.Dashboard
+	// Metrics holds the metrics answers, nil when no metrics backend is wanted.
+	Metrics *metricsAnswers
+	// Tracing holds the tracing answers, nil when no tracing backend is wanted.
+	Tracing *tracingAnswers
+	// Log holds the Traefik log answers.
+	Log logAnswers
+	// AccessLog holds the access log answers, nil when no access log is wanted.
+	AccessLog *accessLogAnswers
+}
+
+// acmeAnswers holds the answers about the ACME certificate resolver.
+type acmeAnswers struct {
+	Email     string `survey:"email"`
+	Storage   string `survey:"storage"`
+	Staging   bool   `survey:"staging"`
+	Challenge string `survey:"challenge"`
+	// DNSProvider is only asked for the DNS-01 challenge.
+	DNSProvider string `survey:"dnsProvider"`
+}
+
+// metricsAnswers holds the answers about the metrics backend.
+type metricsAnswers struct {
+	// Backend is one of the metrics* constants.
+	Backend string
+	// EntryPoint and Buckets are only asked for Prometheus.
+	EntryPoint string
+	Buckets    []float64
+	// ServiceMonitor is only asked for Prometheus on Kubernetes.
+	ServiceMonitor bool
+	// Address, Protocol and PushInterval are only asked for the push backends, the Protocol only for InfluxDB.
+	Address      string
+	Protocol     string
+	PushInterval time.Duration
+}
+
+// tracingAnswers holds the answers about the tracing backend.
+type tracingAnswers struct {
+	// Backend is one of the tracing* constants.
+	Backend     string
+	ServiceName string
+	// Endpoint is the address of the backend, not asked when the backend runs next to Traefik.
+	Endpoint string
+	// Companion runs a local Jaeger or Zipkin next to Traefik.
+	Companion bool
+}
+
+// logAnswers holds the answers about the Traefik log.
+type logAnswers struct {
+	Level  string `survey:"level"`
+	Format string `survey:"format"`
+	// FilePath is empty for the standard output.
+	FilePath string `survey:"filePath"`
+}
+
+// accessLogAnswers holds the answers about the access log.
+type accessLogAnswers struct {
+	Format string `survey:"format"`
+	// FilePath is empty for the standard output.
+	FilePath string `survey:"filePath"`
+	// StatusCodes are the comma separated status code ranges to keep, all the access logs are kept when empty.
+	StatusCodes string `survey:"statusCodes"`
+	// RedactAuthorization is only asked for the json format, which logs the headers.
+	RedactAuthorization bool
+}
+
+// ACME challenge options of the wizard.
+const (
+	challengeTLS  = "TLS-ALPN-01"
+	challengeHTTP = "HTTP-01"
+	challengeDNS  = "DNS-01"
+)
+
+// Dashboard options of the wizard.
+const (
+	dashboardDisabled = "Disabled"
+	dashboardSecure   = "Secure (behind a basic auth on a host)"
+	dashboardInsecure = "Insecure (on the port 8080, without authentication)"
+)
+
+// Metrics backend options of the wizard.
+const (
+	metricsNone       = "None"
+	metricsPrometheus = "Prometheus"
+	metricsDatadog    = "Datadog"
+	metricsStatsD     = "StatsD"
+	metricsInfluxDB   = "InfluxDB"
+)
+
+// Tracing backend options of the wizard.
+const (
+	tracingNone     = "None"
+	tracingJaeger   = "Jaeger"
+	tracingZipkin   = "Zipkin"
+	tracingDatadog  = "Datadog"
+	tracingElastic  = "Elastic"
+	tracingInstana  = "Instana"
+	tracingHaystack = "Haystack"
+)
+
+// tracingEndpoints are the default endpoints of the tracing backends, as asked by the wizard.
+var tracingEndpoints = map[string]string{
+	tracingJaeger:   "127.0.0.1:6831",
+	tracingZipkin:   "http://localhost:9411/api/v2/spans",
+	tracingDatadog:  "localhost:8126",
+	tracingElastic:  "http://localhost:8200",
+	tracingInstana:  "localhost:42699",
+	tracingHaystack: "127.0.0.1:35000",
+}
+
+// metricsEntryPointAddress is the address of the entry point created by the wizard for the Prometheus metrics.
+const metricsEntryPointAddress = ":8082"
+
+// askWizard asks the questions of the interactive wizard.
+func askWizard(formats []export.Format) (wizardAnswers, error) {
+	var options []string
+	for _, format := range formats {
+		options = append(options, format.Description)
+	}
+
+	answers := wizardAnswers{}
+
+	err := survey.Ask([]*survey.Question{
+		{
+			Name: "Format",
+			Prompt: &survey.Select{
+				Message: "Where do you want to define Traefik?",
+				Options: options,
+				Default: options[0],
+				Help:    "https://doc.traefik.io/traefik/v2.4/providers/overview/#supported-providers",
+			},
+		},
+		{
+			Name: "Redirect",
+			Prompt: &survey.Confirm{
+				Message: "Do you want to redirect web to websecure (HTTP to HTTPS)?",
+				Default: true,
+				Help:    "https://doc.traefik.io/traefik/v2.4/routing/entrypoints/#redirection",
+			},
+		},
+	}, &answers)
+	if err != nil {
+		return answers, err
+	}
+
+	answers.ACME, err = askACME()
+	if err != nil {
+		return answers, err
+	}
+
+	answers.Dashboard, answers.DashboardRouter, err = askDashboard()
+	if err != nil {
+		return answers, err
+	}
+
+	kubernetes := false
+	for _, format := range formats {
+		if format.Description == answers.Format {
+			kubernetes = format.Name == "kubernetes"
+		}
+	}
+
+	answers.Metrics, err = askMetrics(kubernetes)
+	if err != nil {
+		return answers, err
+	}
+
+	answers.Tracing, err = askTracing()
+	if err != nil {
+		return answers, err
+	}
+
+	answers.Log, answers.AccessLog, err = askLogs()
+
+	return answers, err
+}
+
+// askACME asks the questions about the Let's Encrypt certificate resolver.
+func askACME() (*acmeAnswers, error) {
+	useACME := false
+	err := survey.AskOne(&survey.Confirm{
+		Message: "Do you want to get certificates from Let's Encrypt?",
+		Default: true,
+		Help:    "https://doc.traefik.io/traefik/v2.4/https/acme/",
+	}, &useACME)
+	if err != nil || !useACME {
+		return nil, err
+	}
+
+	answers := &acmeAnswers{}
+	err = survey.Ask([]*survey.Question{
+		{
+			Name:     "Email",
+			Prompt:   &survey.Input{Message: "Which email address should be used for the registration?"},
+			Validate: survey.Required,
+		},
+		{
+			Name: "Storage",
+			Prompt: &survey.Input{
+				Message: "Where should the certificates be stored?",
+				Default: "/letsencrypt/acme.json",
+			},
+			Validate: survey.Required,
+		},
+		{
+			Name: "Staging",
+			Prompt: &survey.Confirm{
+				Message: "Do you want to use the staging CA server (recommended while testing)?",
+				Help:    "https://letsencrypt.org/docs/staging-environment/",
+			},
+		},
+		{
+			Name: "Challenge",
+			Prompt: &survey.Select{
+				Message: "Which challenge should be used?",
+				Options: []string{challengeTLS, challengeHTTP, challengeDNS},
+				Default: challengeTLS,
+				Help:    "https://letsencrypt.org/docs/challenge-types/",
+			},
+		},
+	}, answers)
+	if err != nil || answers.Challenge != challengeDNS {
+		return answers, err
+	}
+
+	providers := export.DNSProviders()
+	err = survey.AskOne(&survey.Select{
+		Message: "Which DNS provider do you use?",
+		Options: providers,
+		Help:    "https://doc.traefik.io/traefik/v2.4/https/acme/#providers",
+	}, &answers.DNSProvider)
+	if err != nil {
+		return answers, err
+	}
+
+	fmt.Fprintf(os.Stderr, "The %s DNS challenge needs the following environment variables: %s\n",
+		answers.DNSProvider, strings.Join(export.DNSProviderEnvVars(answers.DNSProvider), ", "))
+
+	return answers, nil
+}
+
+// askDashboard asks how the dashboard should be exposed.
+// The router is only returned for the secure mode.
+func askDashboard() (string, *export.Dashboard, error) {
+	mode := dashboardDisabled
+	err := survey.AskOne(&survey.Select{
+		Message: "Do you want to enable the dashboard?",
+		Options: []string{dashboardSecure, dashboardInsecure, dashboardDisabled},
+		Default: dashboardSecure,
+		Help:    "https://doc.traefik.io/traefik/v2.4/operations/dashboard/",
+	}, &mode)
+	if err != nil || mode == dashboardDisabled {
+		return mode, nil, err
+	}
+
+	if mode == dashboardInsecure {
+		fmt.Fprintln(os.Stderr, "WARNING: with api.insecure, anyone who can reach the port 8080 can read the whole routing configuration, "+
+			"including the backend addresses. Never use it in production or on a host reachable from the Internet.")
+
+		confirmed := false
+		err = survey.AskOne(&survey.Confirm{
+			Message: "Do you really want to expose the dashboard without authentication?",
+			Default: false,
+		}, &confirmed)
+		if err != nil || confirmed {
+			return mode, nil, err
+		}
+
+		mode = dashboardSecure
+	}
+
+	credentials := struct {
+		Host     string
+		User     string
+		Password string
+	}{}
+	err = survey.Ask([]*survey.Question{
+		{
+			Name:     "Host",
+			Prompt:   &survey.Input{Message: "On which host should the dashboard be exposed?", Default: "traefik.example.com"},
+			Validate: survey.Required,
+		},
+		{
+			Name:     "User",
+			Prompt:   &survey.Input{Message: "Which user should be allowed to access the dashboard?", Default: "admin"},
+			Validate: survey.Required,
+		},
+		{
+			Name:     "Password",
+			Prompt:   &survey.Password{Message: "Which password should this user type?"},
+			Validate: survey.Required,
+		},
+	}, &credentials)
+	if err != nil {
+		return mode, nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(credentials.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return mode, nil, fmt.Errorf("cannot hash the dashboard password: %w", err)
+	}
+
+	return mode, &export.Dashboard{
+		Host:  credentials.Host,
+		Users: []string{credentials.User + ":" + string(hash)},
+	}, nil
+}
+
+// askMetrics asks which metrics backend should be used and its options.
+func askMetrics(kubernetes bool) (*metricsAnswers, error) {
+	answers := &metricsAnswers{}
+	err := survey.AskOne(&survey.Select{
+		Message: "Which metrics backend do you want to use?",
+		Options: []string{metricsNone, metricsPrometheus, metricsDatadog, metricsStatsD, metricsInfluxDB},
+		Default: metricsNone,
+		Help:    "https://doc.traefik.io/traefik/v2.4/observability/metrics/overview/",
+	}, &answers.Backend)
+	if err != nil || answers.Backend == metricsNone {
+		return nil, err
+	}
+
+	if answers.Backend == metricsPrometheus {
+		return answers, askPrometheus(answers, kubernetes)
+	}
+
+	defaultAddress := "localhost:8125"
+	if answers.Backend == metricsInfluxDB {
+		defaultAddress = "localhost:8089"
+
+		err = survey.AskOne(&survey.Select{
+			Message: "Which protocol should be used to push the metrics to InfluxDB?",
+			Options: []string{"udp", "http"},
+			Default: "udp",
+		}, &answers.Protocol)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pushInterval := ""
+	err = survey.AskOne(&survey.Input{
+		Message: fmt.Sprintf("What is the address of %s?", answers.Backend),
+		Default: defaultAddress,
+	}, &answers.Address, survey.WithValidator(survey.Required))
+	if err != nil {
+		return nil, err
+	}
+
+	err = survey.AskOne(&survey.Input{Message: "How often should the metrics be pushed?", Default: "10s"}, &pushInterval, survey.WithValidator(validateDuration))
+	if err != nil {
+		return nil, err
+	}
+
+	answers.PushInterval, err = time.ParseDuration(pushInterval)
+
+	return answers, err
+}
+
+// askPrometheus asks the options of the Prometheus metrics.
+func askPrometheus(answers *metricsAnswers, kubernetes bool) error {
+	buckets := ""
+	err := survey.AskOne(&survey.Input{
+		Message: "On which entry point should the metrics be served?",
+		Default: "traefik",
+		Help:    "The traefik entry point listens on the port 8080, another name creates an entry point on the port 8082.",
+	}, &answers.EntryPoint, survey.WithValidator(survey.Required))
+	if err != nil {
+		return err
+	}
+
+	err = survey.AskOne(&survey.Input{
+		Message: "Which buckets should be used for the latency metrics?",
+		Default: "0.1,0.3,1.2,5.0",
+	}, &buckets, survey.WithValidator(validateFloats))
+	if err != nil {
+		return err
+	}
+
+	answers.Buckets, err = parseFloats(buckets)
+	if err != nil || !kubernetes {
+		return err
+	}
+
+	return survey.AskOne(&survey.Confirm{
+		Message: "Do you want a ServiceMonitor for the Prometheus Operator?",
+		Help:    "https://github.com/prometheus-operator/prometheus-operator",
+	}, &answers.ServiceMonitor)
+}
+
+// askTracing asks which tracing backend should be used and its endpoint.
+func askTracing() (*tracingAnswers, error) {
+	answers := &tracingAnswers{}
+	err := survey.AskOne(&survey.Select{
+		Message: "Which tracing backend do you want to use?",
+		Options: []string{tracingNone, tracingJaeger, tracingZipkin, tracingDatadog, tracingElastic, tracingInstana, tracingHaystack},
+		Default: tracingNone,
+		Help:    "https://doc.traefik.io/traefik/v2.4/observability/tracing/overview/",
+	}, &answers.Backend)
+	if err != nil || answers.Backend == tracingNone {
+		return nil, err
+	}
+
+	err = survey.AskOne(&survey.Input{
+		Message: "Which service name should be used in the traces?",
+		Default: "traefik",
+	}, &answers.ServiceName, survey.WithValidator(survey.Required))
+	if err != nil {
+		return nil, err
+	}
+
+	if answers.Backend == tracingJaeger || answers.Backend == tracingZipkin {
+		err = survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Do you want to run a local %s next to Traefik (docker and kubernetes outputs)?", answers.Backend),
+			Default: true,
+		}, &answers.Companion)
+		if err != nil || answers.Companion {
+			return answers, err
+		}
+	}
+
+	err = survey.AskOne(&survey.Input{
+		Message: fmt.Sprintf("What is the address of the %s agent or server?", answers.Backend),
+		Default: tracingEndpoints[answers.Backend],
+	}, &answers.Endpoint, survey.WithValidator(survey.Required))
+
+	return answers, err
+}
+
+// askLogs asks the options of the Traefik log and of the access log.
+func askLogs() (logAnswers, *accessLogAnswers, error) {
+	logs := logAnswers{}
+	err := survey.Ask([]*survey.Question{
+		{
+			Name: "Level",
+			Prompt: &survey.Select{
+				Message: "Which level should Traefik log?",
+				Options: []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL", "PANIC"},
+				Default: "ERROR",
+				Help:    "https://doc.traefik.io/traefik/v2.4/observability/logs/",
+			},
+		},
+		{
+			Name: "Format",
+			Prompt: &survey.Select{
+				Message: "Which format should the Traefik logs use?",
+				Options: []string{"common", "json"},
+				Default: "common",
+			},
+		},
+		{
+			Name: "FilePath",
+			Prompt: &survey.Input{
+				Message: "In which file should Traefik write its logs (empty for the standard output)?",
+				Help:    "The directory is mounted as a volume in the docker and kubernetes outputs.",
+			},
+		},
+	}, &logs)
+	if err != nil {
+		return logs, nil, err
+	}
+
+	useAccessLog := false
+	err = survey.AskOne(&survey.Confirm{
+		Message: "Do you want access logs?",
+		Default: true,
+		Help:    "https://doc.traefik.io/traefik/v2.4/observability/access-logs/",
+	}, &useAccessLog)
+	if err != nil || !useAccessLog {
+		return logs, nil, err
+	}
+
+	accessLog := &accessLogAnswers{}
+	err = survey.Ask([]*survey.Question{
+		{
+			Name: "Format",
+			Prompt: &survey.Select{
+				Message: "Which format should the access logs use?",
+				Options: []string{"common", "json"},
+				Default: "common",
+			},
+		},
+		{
+			Name: "FilePath",
+			Prompt: &survey.Input{
+				Message: "In which file should Traefik write the access logs (empty for the standard output)?",
+				Help:    "The directory is mounted as a volume in the docker and kubernetes outputs.",
+			},
+		},
+		{
+			Name: "StatusCodes",
+			Prompt: &survey.Input{
+				Message: "Which status codes should be logged (i.e. 400-599, empty for all)?",
+			},
+		},
+	}, accessLog)
+	if err != nil || accessLog.Format != "json" {
+		return logs, accessLog, err
+	}
+
+	err = survey.AskOne(&survey.Confirm{
+		Message: "Do you want to redact the Authorization header in the access logs?",
+		Default: true,
+	}, &accessLog.RedactAuthorization)
+
+	return logs, accessLog, err
+}
+
+// validateDuration validates a survey answer is a duration (i.e. 10s).
+func validateDuration(answer interface{}) error {
+	_, err := time.ParseDuration(fmt.Sprint(answer))
+	return err
+}
+
+// validateFloats validates a survey answer is a comma separated list of numbers.
+func validateFloats(answer interface{}) error {
+	_, err := parseFloats(fmt.Sprint(answer))
+	return err
+}
+
+// parseFloats parses a comma separated list of numbers.
+func parseFloats(value string) ([]float64, error) {
+	var floats []float64
+	for _, element := range strings.Split(value, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(element), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", element, err)
+		}
+
+		floats = append(floats, f)
+	}
+
+	return floats, nil
+}
+
+// newWizardBuilder creates a builder from the wizard answers, with the provider matching the output format.
+func newWizardBuilder(format string, answers wizardAnswers) *builder.StaticConfBuilder {
+	confBuilder := builder.NewStaticConfBuilder()
+
+	switch format {
+	case "docker":
+		confBuilder.WithDockerProvider()
+	case "kubernetes":
+		confBuilder.WithKubernetesProvider()
+	default:
+		confBuilder.WithFileProvider("conf")
+	}
+
+	var webOptions []builder.EntryPointOption
+	if answers.Redirect {
+		webOptions = append(webOptions, builder.WithRedirection("websecure"))
+	}
+
+	switch answers.Dashboard {
+	case dashboardSecure:
+		confBuilder.WithAPI(builder.WithDashboard())
+	case dashboardInsecure:
+		confBuilder.WithAPI(builder.WithDashboard(), builder.WithInsecureAPI())
+	}
+
+	if answers.Metrics != nil {
+		addMetrics(confBuilder, answers.Metrics)
+	}
+
+	if answers.Tracing != nil {
+		addTracing(confBuilder, answers.Tracing)
+	}
+
+	confBuilder.WithLog(answers.Log.Level, answers.Log.Format, answers.Log.FilePath)
+
+	if answers.AccessLog != nil {
+		confBuilder.WithAccessLog(answers.AccessLog.Format, answers.AccessLog.FilePath, accessLogOptions(answers.AccessLog)...)
+	}
+
+	// The redirection targets websecure, which serves TLS with the default certificate without Let's Encrypt.
+	var websecureOptions []builder.EntryPointOption
+	if answers.ACME != nil {
+		confBuilder.WithACMEResolver("le", answers.ACME.Email, answers.ACME.Storage, acmeOptions(answers.ACME)...)
+		websecureOptions = append(websecureOptions, builder.WithTLS("le"))
+	} else if answers.Redirect {
+		websecureOptions = append(websecureOptions, builder.WithTLS(""))
+	}
+
+	return confBuilder.
+		WithEntryPoint("web", ":8000", webOptions...).
+		WithEntryPoint("websecure", ":8443", websecureOptions...)
+}
+
+// acmeOptions converts the ACME answers to certificate resolver options.
+func acmeOptions(answers *acmeAnswers) []builder.ACMEOption {
+	var opts []builder.ACMEOption
+	if answers.Staging {
+		opts = append(opts, builder.WithStaging())
+	}
+
+	switch answers.Challenge {
+	case challengeHTTP:
+		opts = append(opts, builder.WithHTTPChallenge("web"))
+	case challengeDNS:
+		opts = append(opts, builder.WithDNSChallenge(answers.DNSProvider))
+	default:
+		opts = append(opts, builder.WithTLSChallenge())
+	}
+
+	return opts
+}
+
+// addMetrics adds the metrics backend to the builder.
+// The entry point serving the Prometheus metrics is created, unless it is the traefik one or an entry point of the wizard.
+func addMetrics(confBuilder *builder.StaticConfBuilder, answers *metricsAnswers) {
+	switch answers.Backend {
+	case metricsPrometheus:
+		confBuilder.WithPrometheusMetrics(answers.EntryPoint, answers.Buckets...)
+
+		switch answers.EntryPoint {
+		case "traefik", "web", "websecure":
+		default:
+			confBuilder.WithEntryPoint(answers.EntryPoint, metricsEntryPointAddress)
+		}
+	case metricsDatadog:
+		confBuilder.WithDatadogMetrics(answers.Address, answers.PushInterval)
+	case metricsStatsD:
+		confBuilder.WithStatsDMetrics(answers.Address, answers.PushInterval)
+	case metricsInfluxDB:
+		confBuilder.WithInfluxDBMetrics(answers.Address, answers.Protocol, answers.PushInterval)
+	}
+}
+
+// addTracing adds the tracing backend to the builder.
+// The endpoint is left empty for a companion, which listens on the default local endpoint of the backend.
+func addTracing(confBuilder *builder.StaticConfBuilder, answers *tracingAnswers) {
+	var opt builder.TracingOption
+
+	switch answers.Backend {
+	case tracingJaeger:
+		opt = builder.WithJaeger(answers.Endpoint, "")
+	case tracingZipkin:
+		opt = builder.WithZipkin(answers.Endpoint)
+	case tracingDatadog:
+		opt = builder.WithDatadogTracing(answers.Endpoint)
+	case tracingElastic:
+		opt = builder.WithElastic(answers.Endpoint, "")
+	case tracingInstana:
+		host, port := splitHostPort(answers.Endpoint)
+		opt = builder.WithInstana(host, port)
+	case tracingHaystack:
+		host, port := splitHostPort(answers.Endpoint)
+		opt = builder.WithHaystack(host, port)
+	}
+
+	confBuilder.WithTracing(answers.ServiceName, opt)
+}
+
+// splitHostPort splits an address in a host and a port, the port is zero when missing or invalid.
+func splitHostPort(address string) (string, int) {
+	host, rawPort, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, 0
+	}
+
+	port, err := strconv.Atoi(rawPort)
+	if err != nil {
+		return host, 0
+	}
+
+	return host, port
+}
+
+// accessLogOptions converts the access log answers to access log options.
+func accessLogOptions(answers *accessLogAnswers) []builder.AccessLogOption {
+	var opts []builder.AccessLogOption
+
+	var codes []string
+	for _, code := range strings.Split(answers.StatusCodes, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) > 0 {
+		opts = append(opts, builder.WithStatusCodes(codes...))
+	}
+
+	if answers.RedactAuthorization {
+		opts = append(opts, builder.WithHeaderMode("Authorization", types.AccessLogRedact))
+	}
+
+	return opts
+}